@@ -0,0 +1,292 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
+)
+
+// Types of the attributes scanned using sqlbuilder.ScanTime, matching sqlbuilder.IsTime.
+// Used as written when the type of an attribute could not be resolved.
+var timeTypes = map[string]bool{
+	"time.Time":    true,
+	"*time.Time":   true,
+	"sql.NullTime": true,
+}
+
+// Resolved types of the package declaring the models
+type typeInfo struct {
+	info  *types.Info
+	times []types.Type // time.Time, *time.Time and sql.NullTime
+}
+
+type model struct {
+	Name   string
+	Fields []field
+	file   string
+}
+
+type field struct {
+	Name   string
+	Column string
+	Time   bool
+}
+
+// Generate writes the generated methods of the comma separated model types declared in the package within dir.
+// The output defaults to <goFile>_tinyorm.go, or <type>_tinyorm.go when goFile is empty.
+// Models declared within a _test.go file are written to a _test.go file.
+func Generate(dir string, typeNames string, output string, goFile string) error {
+	if typeNames == "" {
+		return errors.New("-type must be set")
+	}
+
+	pkgName, models, err := parseModels(dir, strings.Split(typeNames, ","))
+	if err != nil {
+		return err
+	}
+
+	src, err := render(pkgName, models)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		output = outputName(models[0], goFile)
+	}
+
+	return os.WriteFile(filepath.Join(dir, output), src, 0644)
+}
+
+func outputName(m model, goFile string) string {
+	base := strings.ToLower(m.Name)
+	if goFile != "" {
+		base = strings.TrimSuffix(strings.TrimSuffix(goFile, ".go"), "_test")
+	}
+
+	if strings.HasSuffix(m.file, "_test.go") {
+		return base + "_tinyorm_test.go"
+	}
+
+	return base + "_tinyorm.go"
+}
+
+// Parses the package within dir, returning the package name and the models in the order of the given type names
+func parseModels(dir string, typeNames []string) (string, []model, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		// Skip previously generated files, they never declare models
+		return !strings.Contains(fi.Name(), "_tinyorm")
+	}, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var pkgName string
+	found := make(map[string]model)
+
+	for name, pkg := range pkgs {
+		var info *typeInfo
+
+		for path, f := range pkg.Files {
+			for _, decl := range f.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok || !contains(typeNames, ts.Name.Name) {
+						continue
+					}
+
+					if ts.TypeParams != nil {
+						return "", nil, fmt.Errorf("generic type %s is not supported", ts.Name.Name)
+					}
+
+					if info == nil {
+						info = typeCheck(fset, name, pkg)
+					}
+
+					m, err := structModel(ts.Name.Name, st, info)
+					if err != nil {
+						return "", nil, err
+					}
+					m.file = filepath.Base(path)
+
+					// Models are generated into a single file and so must share a package
+					if pkgName != "" && pkgName != name {
+						return "", nil, fmt.Errorf("types %s are declared in different packages", strings.Join(typeNames, ", "))
+					}
+					pkgName = name
+					found[m.Name] = m
+				}
+			}
+		}
+	}
+
+	var models []model
+	for _, name := range typeNames {
+		m, ok := found[name]
+		if !ok {
+			return "", nil, fmt.Errorf("struct type %s was not found in %s", name, dir)
+		}
+
+		models = append(models, m)
+	}
+
+	return pkgName, models, nil
+}
+
+// Type checks the package so the attribute types are resolved through import aliases and type aliases.
+// Errors are ignored, i.e. imports which cannot be found, the types which could be resolved are still recorded.
+func typeCheck(fset *token.FileSet, name string, pkg *ast.Package) *typeInfo {
+	var files []*ast.File
+	for _, f := range pkg.Files {
+		files = append(files, f)
+	}
+
+	imp := importer.ForCompiler(fset, "source", nil)
+	ti := &typeInfo{info: &types.Info{Types: make(map[ast.Expr]types.TypeAndValue), Uses: make(map[*ast.Ident]types.Object)}}
+	conf := types.Config{Importer: imp, Error: func(error) {}}
+	conf.Check(name, fset, files, ti.info)
+
+	if timePkg, err := imp.Import("time"); err == nil {
+		t := timePkg.Scope().Lookup("Time").Type()
+		ti.times = append(ti.times, t, types.NewPointer(t))
+	}
+
+	if sqlPkg, err := imp.Import("database/sql"); err == nil {
+		ti.times = append(ti.times, sqlPkg.Scope().Lookup("NullTime").Type())
+	}
+
+	return ti
+}
+
+// Reports if the attribute is scanned using sqlbuilder.ScanTime.
+// The resolved type is used when the type checker could resolve it, else the type is matched as written.
+func (ti *typeInfo) isTime(expr ast.Expr) bool {
+	if t := ti.info.TypeOf(expr); t != nil && t != types.Typ[types.Invalid] && len(ti.times) > 0 {
+		for _, timeType := range ti.times {
+			// Identical sees through type aliases, i.e. type Timestamp = time.Time
+			if types.Identical(t, timeType) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return timeTypes[types.ExprString(expr)]
+}
+
+// Maps the struct fields to columns using the same rules as the reflection of sqlbuilder
+func structModel(name string, st *ast.StructType, info *typeInfo) (model, error) {
+	m := model{Name: name}
+
+	for _, f := range st.Fields.List {
+		var tag string
+		if f.Tag != nil {
+			t, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return m, fmt.Errorf("invalid tag on %s: %v", name, err)
+			}
+			tag = t
+		}
+
+		typeName := types.ExprString(f.Type)
+
+		names := f.Names
+		if len(names) == 0 {
+			// Embedded attributes are named by their type
+			names = []*ast.Ident{ast.NewIdent(typeName[strings.LastIndexAny(typeName, "*.")+1:])}
+		}
+
+		for _, n := range names {
+			column, ok := sqlbuilder.ColumnOf(reflect.StructField{Name: n.Name, Tag: reflect.StructTag(tag)})
+			if !ok {
+				continue
+			}
+
+			m.Fields = append(m.Fields, field{Name: n.Name, Column: column, Time: info.isTime(f.Type)})
+		}
+	}
+
+	return m, nil
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Name of the package variable holding the columns of the model, i.e. tinyUserColumns
+func columnsVar(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+
+	return "tiny" + string(r) + "Columns"
+}
+
+var genTemplate = template.Must(template.New("gen").Funcs(template.FuncMap{"columnsVar": columnsVar}).Parse(`// Code generated by tinyorm gen. DO NOT EDIT.
+
+package {{ .Package }}
+{{ if .Time }}
+import "github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
+{{ end }}
+{{- range .Models }}
+var {{ columnsVar .Name }} = []string{ {{- range $i, $f := .Fields }}{{ if $i }}, {{ end }}{{ printf "%q" $f.Column }}{{ end -}} }
+
+// TinyColumns returns the columns of {{ .Name }} in the order of the model attributes. The returned slice must not be modified.
+func (m *{{ .Name }}) TinyColumns() []string {
+	return {{ columnsVar .Name }}
+}
+
+// TinyScanDest returns the pointers scanned into for each column of {{ .Name }}
+func (m *{{ .Name }}) TinyScanDest() []any {
+	return []any{ {{- range $i, $f := .Fields }}{{ if $i }}, {{ end }}{{ if $f.Time }}sqlbuilder.ScanTime(&m.{{ $f.Name }}){{ else }}&m.{{ $f.Name }}{{ end }}{{ end -}} }
+}
+
+// TinyValues returns the values of each column of {{ .Name }}
+func (m *{{ .Name }}) TinyValues() []any {
+	return []any{ {{- range $i, $f := .Fields }}{{ if $i }}, {{ end }}m.{{ $f.Name }}{{ end -}} }
+}
+{{ end }}`))
+
+// Renders the formatted source of the generated methods
+func render(pkgName string, models []model) ([]byte, error) {
+	var hasTime bool
+	for _, m := range models {
+		for _, f := range m.Fields {
+			hasTime = hasTime || f.Time
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := genTemplate.Execute(&buf, map[string]any{"Package": pkgName, "Time": hasTime, "Models": models}); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const source = `package models
+
+import "time"
+
+type User struct {
+	ID        int
+	FullName  string ` + "`db:\"name\"`" + `
+	First, Last string
+	Skipped   string ` + "`db:\"-\"`" + `
+	Posts     []Post ` + "`tinyorm:\"has_many\"`" + `
+	CreatedAt time.Time
+}
+
+type Post struct {
+	ID     int
+	UserID int
+}
+`
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(source), 0644); err != nil {
+		t.Fatalf("error writing models. error: %v", err.Error())
+	}
+
+	if err := Generate(dir, "User,Post", "", "models.go"); err != nil {
+		t.Fatalf("error generating models. error: %v", err.Error())
+	}
+
+	generated, err := os.ReadFile(filepath.Join(dir, "models_tinyorm.go"))
+	if err != nil {
+		t.Fatalf("error reading generated file. error: %v", err.Error())
+	}
+
+	tests := map[string]struct {
+		want string
+	}{
+		"Test columns":           {want: `var tinyUserColumns = []string{"id", "name", "first", "last", "created_at"}`},
+		"Test scan destinations": {want: `return []any{&m.ID, &m.FullName, &m.First, &m.Last, sqlbuilder.ScanTime(&m.CreatedAt)}`},
+		"Test values":            {want: `return []any{m.ID, m.FullName, m.First, m.Last, m.CreatedAt}`},
+		"Test second model":      {want: `var tinyPostColumns = []string{"id", "user_id"}`},
+		"Test time import":       {want: `import "github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if !strings.Contains(string(generated), test.want) {
+				t.Fatalf("Wanted: %s - Have: %s", test.want, generated)
+			}
+		})
+	}
+
+	t.Run("Test missing type", func(t *testing.T) {
+		if err := Generate(dir, "Comment", "", ""); err == nil {
+			t.Fatal("expected an error generating a missing type")
+		}
+	})
+}
+
+const aliasedSource = `package models
+
+import (
+	dbsql "database/sql"
+	t "time"
+)
+
+// Timestamp is the same type as time.Time
+type Timestamp = t.Time
+
+// Date is a distinct type, scanned directly the same as the reflection of sqlbuilder
+type Date t.Time
+
+type Event struct {
+	ID        int
+	StartsAt  t.Time
+	EndsAt    *t.Time
+	DeletedAt dbsql.NullTime
+	CreatedAt Timestamp
+	Day       Date
+}
+`
+
+func TestGenerateAliasedTimeTypes(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "events.go"), []byte(aliasedSource), 0644); err != nil {
+		t.Fatalf("error writing models. error: %v", err.Error())
+	}
+
+	if err := Generate(dir, "Event", "", "events.go"); err != nil {
+		t.Fatalf("error generating models. error: %v", err.Error())
+	}
+
+	generated, err := os.ReadFile(filepath.Join(dir, "events_tinyorm.go"))
+	if err != nil {
+		t.Fatalf("error reading generated file. error: %v", err.Error())
+	}
+
+	want := `return []any{&m.ID, sqlbuilder.ScanTime(&m.StartsAt), sqlbuilder.ScanTime(&m.EndsAt), sqlbuilder.ScanTime(&m.DeletedAt), sqlbuilder.ScanTime(&m.CreatedAt), &m.Day}`
+	if !strings.Contains(string(generated), want) {
+		t.Fatalf("Wanted: %s - Have: %s", want, generated)
+	}
+}
@@ -0,0 +1,57 @@
+// Command tinyorm provides code generation for tinyorm models.
+//
+// Usage:
+//
+//	tinyorm gen -type User,Post [-output users_tinyorm.go] [dir]
+//
+// gen emits the TinyColumns, TinyScanDest and TinyValues methods for the given model structs.
+// Models implementing these methods are scanned and bound without reflecting over the model attributes.
+// Typically run via go generate from the file declaring the models:
+//
+//	//go:generate go run github.com/BitlyTwiser/tinyORM/cmd/tinyorm gen -type User
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "gen":
+		if err := runGen(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "tinyorm gen: %v\n", err)
+			os.Exit(1)
+		}
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func runGen(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	typeNames := fs.String("type", "", "comma separated list of model struct names; required")
+	output := fs.String("output", "", "output file name; default <file>_tinyorm.go of the file running go generate")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	dir := "."
+	if fs.NArg() > 0 {
+		dir = fs.Arg(0)
+	}
+
+	return Generate(dir, *typeNames, *output, os.Getenv("GOFILE"))
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: tinyorm gen -type User,Post [-output file] [dir]")
+}
@@ -1,11 +1,14 @@
 package connections
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 
 	"github.com/BitlyTwiser/tinyORM/pkg/dialects"
 	"github.com/BitlyTwiser/tinyORM/pkg/logger"
@@ -21,13 +24,82 @@ const (
 	maxOpenConn      = "maxOpenConn"
 )
 
-var Connections = make(map[string]dialects.DialectHandler)
+var (
+	connections = make(map[string]dialects.DialectHandler)
+	mu          sync.RWMutex
+)
+
+// Handlers closed directly, or through a MultiTenantDialectHandler, are removed so the connection can be initialized again
+func init() {
+	dialects.OnClose(deregister)
+}
+
+func deregister(handler dialects.DialectHandler) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for name, handle := range connections {
+		if handle == handler {
+			delete(connections, name)
+		}
+	}
+}
+
+// Connection returns the handler of the connection initialized under the given name
+func Connection(dbConnType string) (dialects.DialectHandler, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	handle, found := connections[dbConnType]
+
+	return handle, found
+}
+
+// Names returns the sorted names of the open connections
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(connections))
+	for name := range connections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Close closes the handler of the connection and removes it, allowing the connection to be initialized again under the same name.
+// Waits up to dialects.DEFAULT_CLOSE_TIMEOUT for the in-flight queries of the connection to finish.
+func Close(dbConnType string) error {
+	mu.Lock()
+	handle, found := connections[dbConnType]
+	delete(connections, dbConnType)
+	mu.Unlock()
+
+	if !found {
+		return fmt.Errorf("database connection %s is not open", dbConnType)
+	}
+
+	return handle.Close()
+}
+
+// CloseAll closes and removes every connection, the in-flight queries of all connections are drained within the given context
+func CloseAll(ctx context.Context) error {
+	mu.Lock()
+	closing := connections
+	connections = make(map[string]dialects.DialectHandler)
+	mu.Unlock()
+
+	return dialects.CloseHandlers(ctx, closing)
+}
 
 // Initialize database connection via loading the database.yml for the given connection.
 // will set the database handlers to the appropriate *sql.DB
+// Initializing a connection which is already open replaces the handler, closing the previous handler after draining its in-flight queries.
 func InitDatabaseConnection(dbConnType string) error {
 	var db *sql.DB
-	var handle dialects.DialectHandler
+	var newHandler func() dialects.DialectHandler
 	var found bool
 
 	config, err := loadDatabaseConfig(dbConnType)
@@ -42,10 +114,11 @@ func InitDatabaseConnection(dbConnType string) error {
 		return fmt.Errorf("database connection %s was not found in database.yml. Please check the file", dbConnType)
 	}
 
-	if handle, found = dialects.Databases[connConfig.Dialect]; !found {
+	if newHandler, found = dialects.Databases[connConfig.Dialect]; !found {
 		return fmt.Errorf("please check provided dialect in database.yml. Provided dialect: %v", connConfig.Dialect)
 	}
 
+	handle := newHandler()
 	handle.SetConfig(*connConfig)
 
 	db, err = sql.Open(connConfig.Dialect, handle.QueryString())
@@ -65,7 +138,15 @@ func InitDatabaseConnection(dbConnType string) error {
 	handle.SetDB(db)
 
 	// Store handler in connections in case of switching handlers
-	Connections[dbConnType] = handle
+	mu.Lock()
+	previous, open := connections[dbConnType]
+	connections[dbConnType] = handle
+	mu.Unlock()
+
+	// The handler replaced is closed once the new connection is stored, so a failed re-initialization keeps the open connection
+	if open {
+		return previous.Close()
+	}
 
 	return nil
 }
@@ -0,0 +1,156 @@
+package connections
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/dialects"
+)
+
+// Runs the test from a temporary directory holding a database.yml with the given sqlite connections
+func withDatabaseFile(t *testing.T, names ...string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	var config string
+	for _, name := range names {
+		config += name + ":\n  dialect: sqlite3\n  path: ./" + name + ".db\n"
+	}
+
+	if err := os.WriteFile(dir+"/"+databaseFileName, []byte(config), 0644); err != nil {
+		t.Fatalf("error writing database.yml. error: %v", err.Error())
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("error getting working directory. error: %v", err.Error())
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("error changing directory. error: %v", err.Error())
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestClose(t *testing.T) {
+	withDatabaseFile(t, "first", "second")
+
+	for _, name := range []string{"first", "second"} {
+		if err := InitDatabaseConnection(name); err != nil {
+			t.Fatalf("error initializing connection %s. error: %v", name, err.Error())
+		}
+	}
+
+	t.Run("close removes the connection", func(t *testing.T) {
+		handle, _ := Connection("first")
+
+		if err := Close("first"); err != nil {
+			t.Fatalf("error closing connection. error: %v", err.Error())
+		}
+
+		if _, found := Connection("first"); found {
+			t.Fatal("Wanted: connection removed - Have: connection found")
+		}
+
+		if err := handle.Exec("SELECT 1"); err == nil {
+			t.Fatal("expected an error querying a closed connection")
+		}
+
+		if err := Close("first"); err == nil {
+			t.Fatal("expected an error closing a connection which is not open")
+		}
+	})
+
+	t.Run("re-open under the same name", func(t *testing.T) {
+		if err := InitDatabaseConnection("first"); err != nil {
+			t.Fatalf("error re-opening connection. error: %v", err.Error())
+		}
+
+		handle, found := Connection("first")
+		if !found {
+			t.Fatal("Wanted: connection found - Have: connection missing")
+		}
+
+		if err := handle.Exec("SELECT 1"); err != nil {
+			t.Fatalf("error querying re-opened connection. error: %v", err.Error())
+		}
+	})
+
+	t.Run("re-initialize an open connection", func(t *testing.T) {
+		previous, _ := Connection("first")
+
+		if err := InitDatabaseConnection("first"); err != nil {
+			t.Fatalf("error re-initializing connection. error: %v", err.Error())
+		}
+
+		if err := previous.Exec("SELECT 1"); err == nil {
+			t.Fatal("expected the replaced connection to be closed")
+		}
+
+		handle, _ := Connection("first")
+		if err := handle.Exec("SELECT 1"); err != nil {
+			t.Fatalf("error querying re-initialized connection. error: %v", err.Error())
+		}
+	})
+
+	t.Run("closing the handler removes the connection", func(t *testing.T) {
+		handle, _ := Connection("first")
+
+		if err := handle.Close(); err != nil {
+			t.Fatalf("error closing handler. error: %v", err.Error())
+		}
+
+		if _, found := Connection("first"); found {
+			t.Fatal("Wanted: connection removed - Have: connection found")
+		}
+
+		if names := Names(); len(names) != 1 || names[0] != "second" {
+			t.Fatalf("Wanted: [second] - Have: %v", names)
+		}
+
+		if err := InitDatabaseConnection("first"); err != nil {
+			t.Fatalf("error re-opening connection. error: %v", err.Error())
+		}
+
+		if _, found := Connection("first"); !found {
+			t.Fatal("Wanted: connection found - Have: connection missing")
+		}
+	})
+
+	t.Run("closing a multi tenant handler removes the connections", func(t *testing.T) {
+		mtc := dialects.MultiTenantDialectHandler{Handlers: make(map[string]dialects.DialectHandler)}
+		for _, name := range []string{"first", "second"} {
+			handle, _ := Connection(name)
+			mtc.Set(name, handle)
+		}
+
+		if err := mtc.Close(); err != nil {
+			t.Fatalf("error closing handlers. error: %v", err.Error())
+		}
+
+		if names := Names(); len(names) != 0 {
+			t.Fatalf("Wanted: no connections - Have: %v", names)
+		}
+
+		for _, name := range []string{"first", "second"} {
+			if err := InitDatabaseConnection(name); err != nil {
+				t.Fatalf("error re-opening connection %s. error: %v", name, err.Error())
+			}
+		}
+	})
+
+	t.Run("close all", func(t *testing.T) {
+		if names := Names(); len(names) != 2 || names[0] != "first" || names[1] != "second" {
+			t.Fatalf("Wanted: [first second] - Have: %v", names)
+		}
+
+		if err := CloseAll(context.Background()); err != nil {
+			t.Fatalf("error closing connections. error: %v", err.Error())
+		}
+
+		if names := Names(); len(names) != 0 {
+			t.Fatalf("Wanted: no connections - Have: %v", names)
+		}
+	})
+}
@@ -7,166 +7,880 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"strconv"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/BitlyTwiser/tinyORM/pkg/logger"
 	"github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
 )
 
-func Create(db *sql.DB, model any, dialectType string) error {
+// Executor is satisfied by both *sql.DB and *sql.Tx, allowing every action to run within or outside of a transaction
+type Executor interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// The created_at and updated_at attributes of the model are set to the current time, unless already set
+// The created row is read back into the model, so values generated by the database such as defaults and serial ids are set.
+// Postgres and SQLite 3.35+ use RETURNING, other databases select the row again by its id.
+// Slices of models are inserted using CreateMany.
+func Create(ctx context.Context, db Executor, model any, dialectType string) (Result, error) {
+	var result Result
+
+	if !sqlbuilder.IsPointer(model) && reflect.ValueOf(model).Elem().Kind() == reflect.Slice {
+		return CreateMany(ctx, db, model, dialectType)
+	}
+
+	if err := beforeCreate(ctx, model); err != nil {
+		return result, err
+	}
+
+	t := now()
+	setTimestamp(model, CREATED_AT, t, false)
+	setTimestamp(model, UPDATED_AT, t, false)
+	initVersion(model)
+
+	// Empty uuid and string ids are generated and set on the model, the same as CreateMany
+	if value := reflect.ValueOf(model); value.Kind() == reflect.Pointer {
+		sqlbuilder.SetMissingID(value)
+	}
+
 	query := sqlbuilder.QueryBuilder("create", model, dialectType)
 
 	if query.Err != nil {
-		return query.Err
+		return result, query.Err
 	}
 
-	stmt, err := db.PrepareContext(context.Background(), query.Query)
+	// Empty integer ids are left for the database to generate, the generated id is set on the model
+	id, generated := generatedID(model)
 
-	if err != nil {
-		return fmt.Errorf("error creating database record. error: %s", err.Error())
+	// The created row is read back so values generated by the database, i.e. defaults, are set on the model
+	if returning(dialectType) {
+		if err := insertReturning(ctx, db, model, query); err != nil {
+			return result, err
+		}
+
+		result.RowsAffected = 1
+		if generated {
+			result.LastInsertId = intID(id)
+		}
+
+		return result, afterCreate(ctx, model)
 	}
 
-	result, err := stmt.Exec(query.Args...)
+	r, err := db.ExecContext(ctx, query.Query, query.Args...)
 
 	if err != nil {
-		return fmt.Errorf("error creating database record. Error: %v", err.Error())
+		return result, fmt.Errorf("error creating database record. Error: %w", err)
 	}
 
-	if c, err := result.RowsAffected(); err != nil {
-		return fmt.Errorf("error creating records. Error: %s Rows Affected: %d", err.Error(), c)
+	if result, err = newResult(r); err != nil {
+		return result, fmt.Errorf("error creating records. Error: %w", err)
+	}
+
+	if generated {
+		setGeneratedID(id, result.LastInsertId)
+	}
+
+	// Without RETURNING the created row is selected again by its id
+	if err := reselect(ctx, db, model, dialectType); err != nil {
+		return result, err
+	}
+
+	return result, afterCreate(ctx, model)
+}
+
+// Postgres and SQLite 3.35+ support returning the inserted row from the INSERT statement
+func returning(dialectType string) bool {
+	switch dialectType {
+	case DIALECT_TYPE_PSQL:
+		return true
+	case DIALECT_TYPE_SQLITE:
+		return sqliteSupportsReturning()
+	}
+
+	return false
+}
+
+// Inserts the model, scanning the inserted row back into the model using RETURNING
+func insertReturning(ctx context.Context, db Executor, model any, query sqlbuilder.Query) error {
+	value := reflect.ValueOf(model)
+
+	row := db.QueryRowContext(ctx, query.Query+" RETURNING "+sqlbuilder.CoalesceQueryBuilder(value.Elem().Type()), query.Args...)
+	if err := row.Scan(sqlbuilder.PointerAttributes(value)...); err != nil {
+		return fmt.Errorf("error creating database record. Error: %w", err)
 	}
 
 	return nil
 }
 
-func Update(db *sql.DB, model any, dialectType string) error {
-	query := sqlbuilder.QueryBuilder("update", model, dialectType)
+// Selects the row of the model by its id, scanning the row into the model.
+// Models without an id attribute, or with an empty id, are left as is.
+func reselect(ctx context.Context, db Executor, model any, dialectType string) error {
+	return reselectBy(ctx, db, model, []string{"id"}, dialectType)
+}
+
+// Selects the row matching the values of the model for the given columns, scanning the row into the model.
+// Models missing an attribute for one of the columns, or with an empty value, are left as is.
+func reselectBy(ctx context.Context, db Executor, model any, columns []string, dialectType string) error {
+	value := reflect.ValueOf(model).Elem()
+
+	var conditions []string
+	var args []any
+	for _, c := range columns {
+		f, found := sqlbuilder.FieldByColumn(value.Type(), c)
+		if !found || value.FieldByIndex(f.Index).IsZero() {
+			return nil
+		}
+
+		conditions = append(conditions, c+" = ?")
+		args = append(args, value.FieldByIndex(f.Index).Interface())
+	}
+
+	query := sqlbuilder.Rebind(fmt.Sprintf("SELECT %s FROM %s WHERE %s", sqlbuilder.CoalesceQueryBuilder(value.Type()), sqlbuilder.TableName(value.Type()), strings.Join(conditions, " AND ")), dialectType, 1)
+	if err := db.QueryRowContext(ctx, query, args...).Scan(sqlbuilder.PointerAttributes(value)...); err != nil {
+		return fmt.Errorf("error selecting created database record. Error: %w", err)
+	}
+
+	return nil
+}
+
+// CreateMany inserts all models of the slice using multi row INSERT statements.
+// Models are grouped by their non empty attributes, empty attributes are left to the database default the same as Create.
+// The rows are split into batches to stay within the parameter limit of the database, use a transaction to insert all batches atomically.
+// Models with an empty uuid or string id have one generated, timestamps and versions are set the same as Create.
+func CreateMany(ctx context.Context, db Executor, model any, dialectType string) (Result, error) {
+	var result Result
+
+	if sqlbuilder.IsPointer(model) {
+		return Result{}, fmt.Errorf("pointer not passed. Please pass a pointer to the model")
+	}
+
+	value := reflect.ValueOf(model).Elem()
+	if value.Kind() != reflect.Slice {
+		return Result{}, fmt.Errorf("must pass in a slice to create many records")
+	}
+
+	if value.Len() == 0 {
+		return result, nil
+	}
 
+	if err := beforeCreate(ctx, model); err != nil {
+		return Result{}, err
+	}
+
+	t := now()
+	models := make([]reflect.Value, value.Len())
+	for i := range models {
+		m := reflect.Indirect(value.Index(i))
+		if !m.IsValid() {
+			return Result{}, fmt.Errorf("cannot create a nil model at index %d", i)
+		}
+
+		sqlbuilder.SetMissingID(m)
+		setTimestamp(m.Addr().Interface(), CREATED_AT, t, false)
+		setTimestamp(m.Addr().Interface(), UPDATED_AT, t, false)
+		initVersion(m.Addr().Interface())
+		models[i] = m
+	}
+
+	tableName := sqlbuilder.TableName(value.Type())
+	for _, group := range sqlbuilder.InsertGroups(models) {
+		if len(group.Columns) == 0 {
+			return result, fmt.Errorf("no attributes were found to create the records")
+		}
+
+		rows, err := insertGroup(ctx, db, tableName, group, dialectType)
+		result.RowsAffected += rows
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, afterCreate(ctx, model)
+}
+
+// Inserts the models of the group in batches, returning the amount of rows inserted
+func insertGroup(ctx context.Context, db Executor, tableName string, group sqlbuilder.InsertGroup, dialectType string) (int64, error) {
+	var inserted int64
+
+	batch := maxParameters(dialectType) / len(group.Columns)
+	for start := 0; start < len(group.Models); start += batch {
+		end := start + batch
+		if end > len(group.Models) {
+			end = len(group.Models)
+		}
+
+		var args []any
+		for _, m := range group.Models[start:end] {
+			args = append(args, sqlbuilder.InsertValues(m, group.Columns)...)
+		}
+
+		query := sqlbuilder.InsertQuery(tableName, group.Columns, end-start, dialectType)
+		r, err := db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return inserted, fmt.Errorf("error creating database records. Error: %w", err)
+		}
+
+		c, err := r.RowsAffected()
+		if err != nil {
+			return inserted, fmt.Errorf("error creating records. Error: %w", err)
+		}
+		inserted += c
+	}
+
+	return inserted, nil
+}
+
+// Upsert inserts the model, updating the update columns of the existing row when the insert conflicts on the conflict columns.
+// When no update columns are given the existing row is left untouched. MySQL ignores the conflict columns, conflicting on any unique key.
+// Hooks, timestamps and versions are handled the same as Create.
+// The row is read back into the model by the conflict columns, or the id for MySQL without conflict columns, so on conflict the model holds the existing row.
+func Upsert(ctx context.Context, db Executor, model any, conflictColumns []string, updateColumns []string, dialectType string) (Result, error) {
+	if err := beforeCreate(ctx, model); err != nil {
+		return Result{}, err
+	}
+
+	t := now()
+	setTimestamp(model, CREATED_AT, t, false)
+	setTimestamp(model, UPDATED_AT, t, true)
+	initVersion(model)
+
+	// The generated id is set on the model, so a new row can be read back by its id
+	if value := reflect.ValueOf(model); value.Kind() == reflect.Pointer {
+		sqlbuilder.SetMissingID(value)
+	}
+
+	query := sqlbuilder.UpsertQuery(model, conflictColumns, updateColumns, dialectType)
 	if query.Err != nil {
-		return query.Err
+		return Result{}, query.Err
 	}
 
-	stmt, err := db.PrepareContext(context.Background(), query.Query)
+	r, err := db.ExecContext(ctx, query.Query, query.Args...)
+	if err != nil {
+		return Result{}, fmt.Errorf("error upserting database record. Error: %w", err)
+	}
 
+	result, err := newResult(r)
 	if err != nil {
-		return err
+		return result, fmt.Errorf("error upserting records. Error: %w", err)
+	}
+
+	keys := conflictColumns
+	if len(keys) == 0 {
+		keys = []string{"id"}
+	}
+
+	if err := reselectBy(ctx, db, model, keys, dialectType); err != nil {
+		return result, err
+	}
+
+	return result, afterCreate(ctx, model)
+}
+
+// Maximum amount of parameters within a single statement for the dialect
+func maxParameters(dialectType string) int {
+	if dialectType == DIALECT_TYPE_SQLITE {
+		return sqliteMaxParameters()
+	}
+
+	return 65535
+}
+
+// Update writes the non empty attributes of the model to the row matching the id of the model.
+// Pass Columns to write only the given columns, including empty attributes. UpdateAll writes every column.
+// The updated_at attribute of the model is set to the current time
+// Models with a version attribute are only updated if the version matches the row, ErrStaleObject is returned otherwise.
+// The version of the model is incremented on success.
+func Update(ctx context.Context, db Executor, model any, dialectType string, opts ...Option) (result Result, err error) {
+	var query string
+	var args []any
+
+	o := applyOptions(opts)
+
+	if err := beforeUpdate(ctx, model); err != nil {
+		return result, err
+	}
+
+	if !o.keepUpdatedAt {
+		setTimestamp(model, UPDATED_AT, now(), true)
+	}
+
+	version, versionColumn, locked := versionField(model)
+	current := int64(0)
+	written := false
+	if locked {
+		current = version.Int()
+		version.SetInt(current + 1)
+
+		// Restore the version if the update did not succeed
+		defer func() {
+			if err != nil && !written {
+				version.SetInt(current)
+			}
+		}()
+	}
+
+	if len(o.columns) > 0 || o.all {
+		query, args, err = updateColumns(model, o, versionColumn, dialectType)
+	} else {
+		query, args, err = updateAttributes(model, dialectType)
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	// Only match the row if it still holds the version the model was read with
+	if locked {
+		query += sqlbuilder.Rebind(fmt.Sprintf(" AND %s = ?", versionColumn), dialectType, len(args)+1)
+		args = append(args, current)
+	}
+
+	r, err := db.ExecContext(ctx, query, args...)
+
+	if err != nil {
+		return result, fmt.Errorf("error updating database record. Error: %w", err)
+	}
+
+	if result, err = newResult(r); err != nil {
+		return result, fmt.Errorf("error updating records. Error: %w", err)
+	}
+
+	if locked && result.RowsAffected == 0 {
+		return result, ErrStaleObject
+	}
+
+	// The row holds the new version, an error of AfterUpdate keeps the version of the model in step with the row
+	written = true
+
+	return result, afterUpdate(ctx, model)
+}
+
+// UpdateAll writes every column of the model to the row matching the id of the model, including empty attributes
+func UpdateAll(ctx context.Context, db Executor, model any, dialectType string) (Result, error) {
+	return Update(ctx, db, model, dialectType, allColumns())
+}
+
+// UpdateMap sets the attributes of the model mapped to the columns of the values, then writes the columns to the row matching the id of the model.
+// updated_at is set to the current time unless given within the values.
+// i.e. UpdateMap(user, map[string]any{"age": 0, "name": ""})
+func UpdateMap(ctx context.Context, db Executor, model any, values map[string]any, dialectType string) (Result, error) {
+	var columns []string
+
+	if sqlbuilder.IsPointer(model) {
+		return Result{}, fmt.Errorf("pointer not passed. Please pass a pointer to the model")
+	}
+
+	value := reflect.ValueOf(model).Elem()
+	if value.Kind() != reflect.Struct {
+		return Result{}, fmt.Errorf("update expects a pointer to a struct")
+	}
+
+	for c := range values {
+		columns = append(columns, c)
+	}
+	sort.Strings(columns)
+
+	for _, c := range columns {
+		f, found := sqlbuilder.FieldByColumn(value.Type(), c)
+		if !found {
+			return Result{}, fmt.Errorf("no attribute was found on model %s for column %s", value.Type().Name(), c)
+		}
+
+		if err := assignValue(value.FieldByIndex(f.Index), values[c]); err != nil {
+			return Result{}, fmt.Errorf("error setting column %s. Error: %w", c, err)
+		}
+	}
+
+	opts := []Option{Columns(columns...)}
+
+	// An updated_at given within the values is written as is, the same as UpdateWhere
+	if _, set := values[UPDATED_AT]; set {
+		opts = append(opts, keepUpdatedAt())
+	}
+
+	return Update(ctx, db, model, dialectType, opts...)
+}
+
+// Builds the update of the non empty attributes of the model
+func updateAttributes(model any, dialectType string) (string, []any, error) {
+	query := sqlbuilder.QueryBuilder("update", model, dialectType)
+
+	if query.Err != nil {
+		return "", nil, query.Err
 	}
 
 	id := query.GetModelID()
 
 	// This should have errored earlier in execution, but just in case
 	if id == nil {
-		return fmt.Errorf("model ID cannot be nil when calling update. Attempt to use a Raw query to update this model")
+		return "", nil, fmt.Errorf("model ID cannot be nil when calling update. Attempt to use a Raw query to update this model")
+	}
+
+	return query.Query, append(query.Args[1:], id), nil
+}
+
+// Builds the update of the selected columns, or every column, of the model including empty attributes.
+// The updated_at and version columns are maintained by Update and are always written.
+func updateColumns(model any, o options, versionColumn string, dialectType string) (string, []any, error) {
+	var columns []string
+
+	if sqlbuilder.IsPointer(model) {
+		return "", nil, fmt.Errorf("pointer not passed. Please pass a pointer to the model")
+	}
+
+	value := reflect.ValueOf(model).Elem()
+	if value.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("update expects a pointer to a struct")
+	}
+
+	idField, found := sqlbuilder.FieldByColumn(value.Type(), "id")
+	if !found || value.FieldByIndex(idField.Index).IsZero() {
+		return "", nil, fmt.Errorf("model ID cannot be empty when calling update. Attempt to use a Raw query to update this model")
+	}
+
+	selected := o.columns
+	if o.all {
+		selected = sqlbuilder.ColumnNames(value.Type())
+	}
+
+	if _, found := sqlbuilder.TimeField(value.Type(), UPDATED_AT); found {
+		selected = append(selected, UPDATED_AT)
 	}
 
-	query.Args = append(query.Args[1:], id)
+	if versionColumn != "" {
+		selected = append(selected, versionColumn)
+	}
 
-	result, err := stmt.Exec(query.Args...)
+	seen := map[string]bool{"id": true}
+	for _, c := range selected {
+		if !seen[c] {
+			seen[c] = true
+			columns = append(columns, c)
+		}
+	}
 
+	args, err := sqlbuilder.ColumnValues(value, columns)
 	if err != nil {
-		return fmt.Errorf("error deleting database record. Error: %v", err.Error())
+		return "", nil, err
 	}
 
-	if c, err := result.RowsAffected(); err != nil {
-		return fmt.Errorf("error deleting records. Error: %s Rows Affected: %d", err.Error(), c)
+	args = append(args, value.FieldByIndex(idField.Index).Interface())
+
+	return sqlbuilder.UpdateColumnsQuery(sqlbuilder.TableName(value.Type()), columns, dialectType), args, nil
+}
+
+// Assigns the value to the attribute, converting between numeric types, i.e. an int value for an int64 attribute.
+// A nil value sets the attribute to its empty value.
+func assignValue(field reflect.Value, v any) error {
+	if v == nil {
+		field.Set(reflect.Zero(field.Type()))
+
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Type().AssignableTo(field.Type()):
+		field.Set(rv)
+	case isNumber(rv.Kind()) && isNumber(field.Kind()):
+		field.Set(rv.Convert(field.Type()))
+	default:
+		return fmt.Errorf("cannot assign %T to %s", v, field.Type())
 	}
 
 	return nil
 }
 
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
 // No id is present within the model and no args are passed, the FIRST recored (using limit 1) from the given model will be deleted
 // To delete records with values other than ID, you can insert a model without an ID, but with other fields present.
 // i.e. to delete a user by name: Delete(&User{name: "carl"})
 // Without an ID field, but with name present, only "carl" will be deleted
 // Multiple attributes will be treated as &'s
-func Delete(db *sql.DB, model any, dialectType string) error {
+// Models with a soft delete attribute, i.e. DeletedAt, are soft deleted. Use HardDelete to remove the rows.
+func Delete(ctx context.Context, db Executor, model any, dialectType string) (Result, error) {
+	if !sqlbuilder.IsPointer(model) {
+		if field, column, found := sqlbuilder.SoftDeleteField(reflect.TypeOf(model).Elem()); found {
+			return softDelete(ctx, db, model, field, column, dialectType)
+		}
+	}
+
+	return HardDelete(ctx, db, model, dialectType)
+}
+
+// HardDelete deletes the rows matching the model the same as Delete, ignoring any soft delete attribute
+func HardDelete(ctx context.Context, db Executor, model any, dialectType string) (Result, error) {
+	if err := beforeDelete(ctx, model); err != nil {
+		return Result{}, err
+	}
+
 	data := sqlbuilder.QueryBuilder("delete", model, dialectType)
 
 	if data.Err != nil {
-		return data.Err
+		return Result{}, data.Err
 	}
 
 	// Means that no attributes were found on model
 	if data.Query == "" {
 		logger.Log.LogEvent("info", "no records were found for the delete query")
-		return nil
+		return Result{}, nil
 	}
 
-	stmt, err := db.PrepareContext(context.Background(), data.Query)
+	r, err := db.ExecContext(ctx, data.Query, data.Args...)
 	if err != nil {
-		return err
+		return Result{}, fmt.Errorf("error deleting database record. Error: %w", err)
 	}
 
-	result, err := stmt.Exec(data.Args...)
+	result, err := newResult(r)
 	if err != nil {
-		return fmt.Errorf("error deleting database record. Error: %v", err.Error())
+		return result, fmt.Errorf("error deleting records. Error: %w", err)
 	}
 
-	if c, err := result.RowsAffected(); err != nil {
-		return fmt.Errorf("error deleting records. Error: %s Rows Affected: %d", err.Error(), c)
+	return result, afterDelete(ctx, model)
+}
+
+// Sets the soft delete column of the rows matching the model, the soft delete attribute of the model is set to the same time
+func softDelete(ctx context.Context, db Executor, model any, field reflect.StructField, column string, dialectType string) (Result, error) {
+	if err := beforeDelete(ctx, model); err != nil {
+		return Result{}, err
 	}
 
-	return nil
+	deletedAt := now()
+	data := sqlbuilder.SoftDeleteQuery(model, column, deletedAt, dialectType)
+
+	if data.Err != nil {
+		return Result{}, data.Err
+	}
+
+	// Means that no attributes were found on model
+	if data.Query == "" {
+		logger.Log.LogEvent("info", "no records were found for the delete query")
+		return Result{}, nil
+	}
+
+	r, err := db.ExecContext(ctx, data.Query, data.Args...)
+	if err != nil {
+		return Result{}, fmt.Errorf("error soft deleting database record. Error: %w", err)
+	}
+
+	result, err := newResult(r)
+	if err != nil {
+		return result, fmt.Errorf("error soft deleting records. Error: %w", err)
+	}
+
+	setTime(reflect.ValueOf(model).Elem().FieldByIndex(field.Index), deletedAt)
+
+	return result, afterDelete(ctx, model)
 }
 
-// To delete results in bulk, pass in a slice. This will batch delete records for the given Model
-func BulkDelete(db *sql.DB, model any, dialectType string) error {
-	data := sqlbuilder.QueryBuilder("delete", model, dialectType)
+// BulkDelete deletes the rows of all models within the slice by their ids, using batched WHERE id IN (...) queries.
+// Every model must have an id. Models with a soft delete attribute are soft deleted. The amount of rows deleted is returned.
+func BulkDelete(ctx context.Context, db Executor, model any, dialectType string) (int64, error) {
+	var ids []any
 
-	if data.Err != nil {
-		return data.Err
+	if sqlbuilder.IsPointer(model) {
+		return 0, fmt.Errorf("pointer not passed. Please pass a pointer to the model")
 	}
 
-	m := reflect.Indirect(reflect.ValueOf(model))
+	m := reflect.ValueOf(model).Elem()
 	if m.Kind() != reflect.Slice {
-		return fmt.Errorf("must pass in a slice to bulk delete records")
+		return 0, fmt.Errorf("must pass in a slice to bulk delete records")
+	}
+
+	if m.Len() == 0 {
+		return 0, nil
+	}
+
+	modelType := sqlbuilder.ModelType(model)
+	idField, found := sqlbuilder.FieldByColumn(modelType, "id")
+	if !found {
+		return 0, fmt.Errorf("model %s must have an id to bulk delete records", modelType.Name())
+	}
+
+	for i := 0; i < m.Len(); i++ {
+		v := reflect.Indirect(m.Index(i))
+		if !v.IsValid() || v.FieldByIndex(idField.Index).IsZero() {
+			return 0, fmt.Errorf("model at index %d has no id, refusing to bulk delete records", i)
+		}
+
+		ids = append(ids, v.FieldByIndex(idField.Index).Interface())
+	}
+
+	if err := beforeDelete(ctx, model); err != nil {
+		return 0, err
+	}
+
+	// Models with a soft delete attribute are soft deleted, the deleted at argument takes a parameter of each batch
+	field, column, soft := sqlbuilder.SoftDeleteField(modelType)
+	batch := maxParameters(dialectType)
+	if soft {
+		batch--
+	}
+
+	var deleted int64
+	deletedAt := now()
+	tableName := sqlbuilder.TableName(reflect.TypeOf(model).Elem())
+	for start := 0; start < len(ids); start += batch {
+		end := start + batch
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		condition := fmt.Sprintf("id IN (%s)", sqlbuilder.Placeholders(end-start))
+		rows, err := deleteRows(ctx, db, tableName, condition, ids[start:end], column, deletedAt, dialectType)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += rows
+	}
+
+	logger.Log.LogEvent("info", "Deleted rows", "rows deleted", deleted)
+
+	if soft {
+		for i := 0; i < m.Len(); i++ {
+			setTime(reflect.Indirect(m.Index(i)).FieldByIndex(field.Index), deletedAt)
+		}
+	}
+
+	return deleted, afterDelete(ctx, model)
+}
+
+// Confirmation must be passed to DeleteAll to confirm every row of the table is deleted
+type Confirmation string
+
+// ConfirmAll confirms DeleteAll deletes every row of the table
+const ConfirmAll Confirmation = "delete all rows"
+
+// DeleteAll deletes every row of the table of the model, the model may be a pointer to a model or a slice of models.
+// ConfirmAll must be passed to guard against wiping a table by accident.
+// Models with a soft delete attribute are soft deleted. The amount of rows deleted is returned.
+func DeleteAll(ctx context.Context, db Executor, model any, confirm Confirmation, dialectType string) (int64, error) {
+	if confirm != ConfirmAll {
+		return 0, fmt.Errorf("DeleteAll must be confirmed by passing ConfirmAll")
+	}
+
+	if sqlbuilder.IsPointer(model) {
+		return 0, fmt.Errorf("pointer not passed. Please pass a pointer to the model")
+	}
+
+	if err := beforeDelete(ctx, model); err != nil {
+		return 0, err
+	}
+
+	var column string
+	if c, soft := sqlbuilder.SoftDeleteColumn(sqlbuilder.ModelType(model)); soft {
+		column = c
 	}
 
-	stmt, err := db.PrepareContext(context.Background(), fmt.Sprintf("DELETE FROM %s", data.TableName))
+	deleted, err := deleteRows(ctx, db, sqlbuilder.TableName(reflect.TypeOf(model).Elem()), "", nil, column, now(), dialectType)
 	if err != nil {
-		return err
+		return deleted, err
+	}
+
+	logger.Log.LogEvent("info", "Deleted rows", "rows deleted", deleted)
+
+	return deleted, afterDelete(ctx, model)
+}
+
+// UpdateWhere sets the columns of the values on every row matching the statement in a single query, the amount of rows updated is returned.
+// The model is only used to find the table, i.e. UpdateWhere(&User{}, map[string]any{"active": false}, "last_login < ?", cutoff)
+// The updated_at column is set to the current time unless given within the values. Soft deleted rows are not updated.
+// Hooks are not called as no models are loaded.
+func UpdateWhere(ctx context.Context, db Executor, model any, values map[string]any, stmt string, dialectType string, args ...any) (int64, error) {
+	var columns []string
+	var sets []string
+	var conditions []string
+
+	if sqlbuilder.IsPointer(model) {
+		return 0, fmt.Errorf("pointer not passed. Please pass a pointer to the model")
 	}
-	result, err := stmt.Exec()
+
+	if stmt == "" {
+		return 0, errors.New("you cannot pass an empty statement")
+	}
+
+	if len(values) == 0 {
+		return 0, errors.New("you must provide values to update")
+	}
+
+	modelType := sqlbuilder.ModelType(model)
+	for c := range values {
+		if _, found := sqlbuilder.FieldByColumn(modelType, c); !found {
+			return 0, fmt.Errorf("no attribute was found on model %s for column %s", modelType.Name(), c)
+		}
+		columns = append(columns, c)
+	}
+	sort.Strings(columns)
+
+	params := make([]any, 0, len(columns)+len(args))
+	for _, c := range columns {
+		sets = append(sets, c+" = ?")
+		params = append(params, values[c])
+	}
+
+	_, found := sqlbuilder.TimeField(modelType, UPDATED_AT)
+	if _, set := values[UPDATED_AT]; found && !set {
+		sets = append(sets, UPDATED_AT+" = ?")
+		params = append(params, now())
+	}
+
+	conditions = append(conditions, "("+stmt+")")
+	if column, soft := sqlbuilder.SoftDeleteColumn(modelType); soft {
+		conditions = append(conditions, column+" IS NULL")
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", sqlbuilder.TableName(modelType), strings.Join(sets, ", "), strings.Join(conditions, " AND "))
+
+	result, err := db.ExecContext(ctx, sqlbuilder.Rebind(query, dialectType, 1), append(params, args...)...)
 	if err != nil {
-		return err
+		return 0, fmt.Errorf("error updating database records. Error: %w", err)
 	}
 
-	rowsDeleted, err := result.RowsAffected()
+	updated, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	logger.Log.LogEvent("info", "Deleted rows", "rows deleted", rowsDeleted)
+	logger.Log.LogEvent("info", "Updated rows", "rows updated", updated)
 
-	return nil
+	return updated, nil
+}
+
+// DeleteWhere deletes every row matching the statement in a single query, the amount of rows deleted is returned.
+// The model is only used to find the table, i.e. DeleteWhere(&User{}, "age < ?", 18)
+// Models with a soft delete attribute are soft deleted. Hooks are not called as no models are loaded.
+func DeleteWhere(ctx context.Context, db Executor, model any, stmt string, dialectType string, args ...any) (int64, error) {
+	var column string
+
+	if sqlbuilder.IsPointer(model) {
+		return 0, fmt.Errorf("pointer not passed. Please pass a pointer to the model")
+	}
+
+	if stmt == "" {
+		return 0, errors.New("you cannot pass an empty statement, use DeleteAll to delete every row")
+	}
+
+	modelType := sqlbuilder.ModelType(model)
+	if c, soft := sqlbuilder.SoftDeleteColumn(modelType); soft {
+		column = c
+	}
+
+	deleted, err := deleteRows(ctx, db, sqlbuilder.TableName(modelType), "("+stmt+")", args, column, now(), dialectType)
+	if err != nil {
+		return deleted, err
+	}
+
+	logger.Log.LogEvent("info", "Deleted rows", "rows deleted", deleted)
+
+	return deleted, nil
+}
+
+// Deletes the rows of the table matching the condition, an empty condition matches all rows.
+// When a soft delete column is given, the column of the rows which are not yet deleted is set to deletedAt instead.
+func deleteRows(ctx context.Context, db Executor, tableName string, condition string, args []any, softDeleteColumn string, deletedAt time.Time, dialectType string) (int64, error) {
+	var conditions []string
+
+	if condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s", tableName)
+	if softDeleteColumn != "" {
+		query = fmt.Sprintf("UPDATE %s SET %s = ?", tableName, softDeleteColumn)
+		args = append([]any{deletedAt}, args...)
+		conditions = append(conditions, softDeleteColumn+" IS NULL")
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	result, err := db.ExecContext(ctx, sqlbuilder.Rebind(query, dialectType, 1), args...)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting database records. Error: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// Joins the condition onto a query using the given keyword, an empty condition returns an empty string
+func whereClause(keyword string, condition string) string {
+	if condition == "" {
+		return ""
+	}
+
+	return keyword + condition
+}
+
+// Grows the slice by one zero element, returning the slice and the addressable element to scan the row into.
+// Rows are scanned in place rather than copied into the slice by reflect.Append.
+func appendElem(s reflect.Value) (reflect.Value, reflect.Value) {
+	n := s.Len()
+	if n == s.Cap() {
+		grown := reflect.MakeSlice(s.Type(), n, 2*n+8)
+		reflect.Copy(grown, s)
+		s = grown
+	}
+	s = s.Slice(0, n+1)
+
+	return s, s.Index(n)
 }
 
 // Will accept arbitrary arguments, though only 1 is used, which should be the ID of the object to find.
+// Options, i.e. Preload, may be passed alongside the ID and are not treated as arguments.
 // If an ID is not passed, ALL objects of the model will be returned
 // If there is no id and the passed model is not a slice, the first row is returned for the given model
 // If an ID IS passed, only a single object should ever be found.
 // If an ID is passed, the the model is converted into a slice of model type
-func Find(db *sql.DB, model any, dialectType string, args ...any) error {
+func Find(ctx context.Context, db Executor, model any, dialectType string, args ...any) error {
+	args, opts := splitOptions(args)
+
+	if err := find(ctx, db, model, dialectType, opts, args...); err != nil {
+		return err
+	}
+
+	if err := preload(ctx, db, dialectType, model, opts.preload); err != nil {
+		return err
+	}
+
+	return afterFind(ctx, model)
+}
+
+func find(ctx context.Context, db Executor, model any, dialectType string, opts options, args ...any) error {
 	var querySymbol string = "?"
+	var scope string
 	data := sqlbuilder.QueryBuilder("find", model, dialectType)
 
 	if data.Err != nil {
 		return data.Err
 	}
 
+	// Soft deleted rows are excluded unless the find is unscoped
+	if column, found := sqlbuilder.SoftDeleteColumn(sqlbuilder.ModelType(model)); found && !opts.unscoped {
+		scope = column + " IS NULL"
+	}
+
 	// If value is not slice kind and args == 0
 	value := reflect.Indirect(reflect.ValueOf(model))
 	if len(args) == 0 && value.Kind() == reflect.Slice {
 		// Make sure its a slice.
 
-		stmt, err := db.PrepareContext(context.Background(), fmt.Sprintf("SELECT %s FROM %s", sqlbuilder.CoalesceQueryBuilder(value.Type().Elem()), data.TableName))
-
-		if err != nil {
-			return err
-		}
-
-		rows, err := stmt.Query(args...)
+		rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT %s FROM %s%s", sqlbuilder.CoalesceQueryBuilder(value.Type().Elem()), data.TableName, whereClause(" WHERE ", scope)))
 
 		if err != nil {
 			return err
@@ -182,17 +896,15 @@ func Find(db *sql.DB, model any, dialectType string, args ...any) error {
 		newS := reflect.MakeSlice(reflect.SliceOf(value.Type().Elem()), 0, 0)
 
 		for rows.Next() {
-			// Create new pointer to inner struct type
-			newVal := reflect.New(value.Type().Elem())
+			// Grow the slice by a new element of the inner struct type
+			var newVal reflect.Value
+			newS, newVal = appendElem(newS)
 
 			// Fill model with data after parsing out attributes for struct
 			err := rows.Scan(sqlbuilder.PointerAttributes(newVal)...)
 			if err != nil {
 				return err
 			}
-
-			// Append slice of new val
-			newS = reflect.Append(newS, newVal.Elem())
 		}
 
 		// Ensure rows did not encounter an error when calling Next()
@@ -212,14 +924,8 @@ func Find(db *sql.DB, model any, dialectType string, args ...any) error {
 
 	// If no args passed and no slice passed, return first value
 	if len(args) == 0 && value.Kind() != reflect.Slice {
-		s := fmt.Sprintf("SELECT %s FROM %s LIMIT 1", sqlbuilder.CoalesceQueryBuilder(value.Type()), data.TableName)
-		stmt, err := db.PrepareContext(context.Background(), s)
-
-		if err != nil {
-			return err
-		}
-
-		row := stmt.QueryRow()
+		s := fmt.Sprintf("SELECT %s FROM %s%s LIMIT 1", sqlbuilder.CoalesceQueryBuilder(value.Type()), data.TableName, whereClause(" WHERE ", scope))
+		row := db.QueryRowContext(ctx, s)
 
 		if err := row.Scan(data.ModelAttributes()...); err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
@@ -227,7 +933,7 @@ func Find(db *sql.DB, model any, dialectType string, args ...any) error {
 				return err
 			}
 
-			return fmt.Errorf("error records for table: %s. Error: %v", data.TableName, err.Error())
+			return fmt.Errorf("error records for table: %s. Error: %w", data.TableName, err)
 		}
 
 		return nil
@@ -237,19 +943,14 @@ func Find(db *sql.DB, model any, dialectType string, args ...any) error {
 	if dialectType == "psql" {
 		querySymbol = "$1"
 	}
-	s := fmt.Sprintf("SELECT %s FROM %s WHERE id = %s", sqlbuilder.CoalesceQueryBuilder(value.Type()), data.TableName, querySymbol)
-	stmt, err := db.PrepareContext(context.Background(), s)
-	if err != nil {
-		return err
-	}
-
-	row := stmt.QueryRow(args[0])
+	s := fmt.Sprintf("SELECT %s FROM %s WHERE id = %s%s", sqlbuilder.CoalesceQueryBuilder(value.Type()), data.TableName, querySymbol, whereClause(" AND ", scope))
+	row := db.QueryRowContext(ctx, s, args[0])
 	if err := row.Scan(data.ModelAttributes()...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return err
 		}
 
-		return fmt.Errorf("error scanning rows for id: %v. Error: %v", args[0], err.Error())
+		return fmt.Errorf("error scanning rows for id: %v. Error: %w", args[0], err)
 	}
 
 	return nil
@@ -258,8 +959,8 @@ func Find(db *sql.DB, model any, dialectType string, args ...any) error {
 // Will return all rows found unless <= 1 rows are present in result of query
 // Will accept a limit, limit of <= 0 will return all rows found matching the query
 // Where is an all in 1 method with no chaining. Pass in the model, statement, desired limit (if there is one, else pass in <= 0), and any arguments to satiate the query
-func Where(db *sql.DB, model any, stmt string, limit int, dialectType string, args ...any) error {
-	var parsedStmt strings.Builder
+func Where(ctx context.Context, db Executor, model any, stmt string, limit int, dialectType string, args ...any) error {
+	args, opts := splitOptions(args)
 
 	if stmt == "" {
 		return errors.New("you cannot pass an empty statement")
@@ -269,116 +970,36 @@ func Where(db *sql.DB, model any, stmt string, limit int, dialectType string, ar
 		return errors.New("you must provide attributes for the sql query")
 	}
 
-	data := sqlbuilder.QueryBuilder("where", model, dialectType)
-
-	if data.Err != nil {
-		return data.Err
-	}
-
-	i := 1
-	for _, v := range stmt {
-		// Handle PSQL conversion
-		if v == '?' && dialectType == "psql" {
-			parsedStmt.WriteString("$" + strconv.Itoa(i))
-			i++
-			continue
-		}
-
-		parsedStmt.WriteRune(v)
-	}
-
-	value := reflect.Indirect(reflect.ValueOf(model))
-	// If slice we will scan rows and insert data based off of incoming stmt
-	if value.Kind() == reflect.Slice {
-		query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", sqlbuilder.CoalesceQueryBuilder(value.Type().Elem()), data.TableName, parsedStmt.String())
-		if limit > 0 {
-			query = query + fmt.Sprintf(" LIMIT %d", limit)
-		}
-		s, err := db.PrepareContext(context.Background(), query)
-
-		if err != nil {
-			return err
-		}
-
-		rows, err := s.Query(args...)
-
-		if err != nil {
-			return nil
-		}
-
-		defer func() {
-			if err := rows.Close(); err != nil {
-				logger.Log.LogError("error closing database rows.", err)
-			}
-		}()
-
-		newS := reflect.MakeSlice(reflect.SliceOf(value.Type().Elem()), 0, 0)
-		for rows.Next() {
-			newVal := reflect.New(value.Type().Elem())
-
-			if err := rows.Scan(sqlbuilder.PointerAttributes(newVal)...); err != nil {
-				if errors.Is(err, sql.ErrNoRows) {
-					return err
-				}
-				return err
-			}
-
-			newS = reflect.Append(newS, newVal.Elem())
-		}
-
-		if err := rows.Err(); err != nil {
-			return err
-		}
-
-		// Check if we can set the model, if we can, insert newslice
-		v := reflect.ValueOf(model).Elem()
-		if v.CanSet() {
-			v.Set(newS)
-		}
-
-		return rows.Close()
-	}
-
-	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", sqlbuilder.CoalesceQueryBuilder(value.Type()), data.TableName, parsedStmt.String())
-	if limit > 0 {
-		query = query + fmt.Sprintf(" LIMIT %d", limit)
-	}
-	s, err := db.PrepareContext(context.Background(), query)
+	return newModelQuery(db, model, dialectType).Context(ctx).Where(stmt, args...).Limit(limit).apply(opts).All()
+}
 
+// Exec executes the query directly without preparing a statement.
+// Useful for queries containing multiple statements, i.e. migrations, for the drivers that support it.
+func Exec(ctx context.Context, db Executor, query string, args ...any) error {
+	result, err := db.ExecContext(Unprepared(ctx), query, args...)
 	if err != nil {
-		return err
+		return fmt.Errorf("error executing query. Error: %w", err)
 	}
 
-	// If not slice, scan row
-	row := s.QueryRow(args...)
-
-	if err := row.Scan(data.ModelAttributes()...); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return err
-		}
+	if c, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("error executing query. Error: %w Rows Affected: %d", err, c)
 	}
 
 	return nil
 }
 
-// Raw builds a raw query, allowing for a user to either call Exec or All functions to perform execution
-func Raw(db *sql.DB, query string, args ...any) (*RawQuery, error) {
-	stmt, err := db.PrepareContext(context.Background(), query)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if len(args) > 0 {
-		return &RawQuery{
-			stmt:  stmt,
-			query: query,
-			args:  args,
-		}, nil
+// Raw builds a raw query, allowing for a user to either call Exec or All functions to perform execution.
+// The statement is prepared using the statement cache of the handler when the query is executed.
+// Exec and All use the given context, ExecContext and AllContext replace it.
+func Raw(ctx context.Context, db Executor, query string, args ...any) (*RawQuery, error) {
+	if query == "" {
+		return nil, errors.New("you cannot pass an empty query")
 	}
 
 	return &RawQuery{
-		stmt:  stmt,
+		ctx:   ctx,
+		db:    db,
 		query: query,
+		args:  args,
 	}, nil
 }
@@ -0,0 +1,278 @@
+package dialects
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/logger"
+	"github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
+)
+
+// Association manages the join table rows of a many to many relation of the model.
+// The model and all associated models must already be created, only the join table rows are altered.
+// i.e. db.Association(&user, "Tags").Append(&tag)
+type Association struct {
+	ctx         context.Context
+	db          Executor
+	model       any
+	dialectType string
+	relation    sqlbuilder.Relation
+	err         error
+}
+
+func newAssociation(db Executor, model any, name string, dialectType string) *Association {
+	a := &Association{
+		ctx:         context.Background(),
+		db:          db,
+		model:       model,
+		dialectType: dialectType,
+	}
+
+	if sqlbuilder.IsPointer(model) || reflect.TypeOf(model).Elem().Kind() != reflect.Struct {
+		a.err = fmt.Errorf("pointer not passed. Please pass a pointer to the model")
+
+		return a
+	}
+
+	modelType := reflect.TypeOf(model).Elem()
+	a.relation, a.err = sqlbuilder.RelationOf(modelType, name)
+	if a.err == nil && a.relation.Kind != sqlbuilder.MANY_TO_MANY {
+		a.err = fmt.Errorf("attribute %s on model %s is not a %s relation", name, modelType.Name(), sqlbuilder.MANY_TO_MANY)
+	}
+
+	return a
+}
+
+// Context sets the context used when altering the join table
+func (a *Association) Context(ctx context.Context) *Association {
+	a.ctx = ctx
+
+	return a
+}
+
+// Append associates the given models with the model. Models which are already associated are skipped.
+func (a *Association) Append(values ...any) error {
+	id, associated, err := a.prepare(values)
+	if err != nil {
+		return err
+	}
+
+	existing, err := a.associatedKeys(id)
+	if err != nil {
+		return err
+	}
+
+	query := sqlbuilder.Rebind(fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (?, ?)", a.relation.JoinTable, a.relation.JoinForeignKey, a.relation.JoinReferences), a.dialectType, 1)
+	for _, v := range associated {
+		key := relationKey(v.id)
+		if _, found := existing[key]; found {
+			continue
+		}
+
+		if _, err := a.db.ExecContext(a.ctx, query, id.Interface(), v.id.Interface()); err != nil {
+			return fmt.Errorf("error appending to %s. Error: %w", a.relation.JoinTable, err)
+		}
+
+		existing[key] = struct{}{}
+	}
+
+	a.appendField(associated)
+
+	return nil
+}
+
+// Remove removes the association between the model and the given models, the associated models are not deleted.
+func (a *Association) Remove(values ...any) error {
+	id, associated, err := a.prepare(values)
+	if err != nil {
+		return err
+	}
+
+	if len(associated) == 0 {
+		return nil
+	}
+
+	var keys []any
+	removed := make(map[string]struct{})
+	for _, v := range associated {
+		keys = append(keys, v.id.Interface())
+		removed[relationKey(v.id)] = struct{}{}
+	}
+
+	// The keys are split into batches to stay within the parameter limit, the id of the model takes one parameter
+	for _, batch := range keyBatches(keys, maxParameters(a.dialectType)-1) {
+		query := fmt.Sprintf("DELETE FROM %s WHERE %s = ? AND %s IN (%s)", a.relation.JoinTable, a.relation.JoinForeignKey, a.relation.JoinReferences, sqlbuilder.Placeholders(len(batch)))
+		if _, err := a.db.ExecContext(a.ctx, sqlbuilder.Rebind(query, a.dialectType, 1), append([]any{id.Interface()}, batch...)...); err != nil {
+			return fmt.Errorf("error removing from %s. Error: %w", a.relation.JoinTable, err)
+		}
+	}
+
+	a.removeField(removed)
+
+	return nil
+}
+
+// Replace replaces all associations of the model with the given models.
+// Use within a transaction to replace the associations atomically.
+func (a *Association) Replace(values ...any) error {
+	if err := a.Clear(); err != nil {
+		return err
+	}
+
+	return a.Append(values...)
+}
+
+// Clear removes all associations of the model, the associated models are not deleted.
+func (a *Association) Clear() error {
+	id, _, err := a.prepare(nil)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s = ?", a.relation.JoinTable, a.relation.JoinForeignKey)
+	if _, err := a.db.ExecContext(a.ctx, sqlbuilder.Rebind(query, a.dialectType, 1), id.Interface()); err != nil {
+		return fmt.Errorf("error clearing %s. Error: %w", a.relation.JoinTable, err)
+	}
+
+	field := a.field()
+	field.Set(reflect.MakeSlice(field.Type(), 0, 0))
+
+	return nil
+}
+
+type associatedValue struct {
+	model reflect.Value // Struct value of the associated model
+	id    reflect.Value
+}
+
+// Validates the model and the given associated models, returning the id of the model and the associated models
+func (a *Association) prepare(values []any) (reflect.Value, []associatedValue, error) {
+	var associated []associatedValue
+
+	if a.err != nil {
+		return reflect.Value{}, nil, a.err
+	}
+
+	modelType := reflect.TypeOf(a.model).Elem()
+	id, err := idValue(reflect.ValueOf(a.model).Elem())
+	if err != nil {
+		return id, nil, fmt.Errorf("model %s must be created before altering associations. Error: %w", modelType.Name(), err)
+	}
+
+	for _, value := range values {
+		v := reflect.Indirect(reflect.ValueOf(value))
+		if v.Type() != a.relation.Model {
+			return id, nil, fmt.Errorf("cannot associate %s with attribute %s of model %s", v.Type().Name(), a.relation.Field.Name, modelType.Name())
+		}
+
+		childID, err := idValue(v)
+		if err != nil {
+			return id, nil, fmt.Errorf("model %s must be created before it is associated. Error: %w", v.Type().Name(), err)
+		}
+
+		associated = append(associated, associatedValue{model: v, id: childID})
+	}
+
+	return id, associated, nil
+}
+
+// Returns the keys of all models currently associated with the model
+func (a *Association) associatedKeys(id reflect.Value) (map[string]struct{}, error) {
+	keys := make(map[string]struct{})
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s = ?", a.relation.JoinReferences, a.relation.JoinTable, a.relation.JoinForeignKey)
+	rows, err := a.db.QueryContext(a.ctx, sqlbuilder.Rebind(query, a.dialectType, 1), id.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("error querying %s. Error: %w", a.relation.JoinTable, err)
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.LogError("error closing database rows.", err)
+		}
+	}()
+
+	for rows.Next() {
+		var key any
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+
+		keys[relationKey(reflect.ValueOf(key))] = struct{}{}
+	}
+
+	return keys, rows.Err()
+}
+
+// The relation attribute of the model
+func (a *Association) field() reflect.Value {
+	return reflect.ValueOf(a.model).Elem().FieldByIndex(a.relation.Field.Index)
+}
+
+// Appends the associated models to the relation attribute of the model, skipping models already present
+func (a *Association) appendField(associated []associatedValue) {
+	field := a.field()
+	present := make(map[string]struct{})
+
+	for i := 0; i < field.Len(); i++ {
+		if id, err := idValue(reflect.Indirect(field.Index(i))); err == nil {
+			present[relationKey(id)] = struct{}{}
+		}
+	}
+
+	for _, v := range associated {
+		key := relationKey(v.id)
+		if _, found := present[key]; found {
+			continue
+		}
+		present[key] = struct{}{}
+
+		if field.Type().Elem().Kind() == reflect.Ptr {
+			ptr := reflect.New(v.model.Type())
+			ptr.Elem().Set(v.model)
+			field.Set(reflect.Append(field, ptr))
+
+			continue
+		}
+
+		field.Set(reflect.Append(field, v.model))
+	}
+}
+
+// Removes the models with the given keys from the relation attribute of the model
+func (a *Association) removeField(removed map[string]struct{}) {
+	field := a.field()
+	kept := reflect.MakeSlice(field.Type(), 0, field.Len())
+
+	for i := 0; i < field.Len(); i++ {
+		if id, err := idValue(reflect.Indirect(field.Index(i))); err == nil {
+			if _, found := removed[relationKey(id)]; found {
+				continue
+			}
+		}
+
+		kept = reflect.Append(kept, field.Index(i))
+	}
+
+	field.Set(kept)
+}
+
+// Returns the non zero id attribute of the struct value
+func idValue(v reflect.Value) (reflect.Value, error) {
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("model must be a struct")
+	}
+
+	f, found := sqlbuilder.FieldByColumn(v.Type(), "id")
+	if !found {
+		return reflect.Value{}, fmt.Errorf("no attribute was found on model %s for column id", v.Type().Name())
+	}
+
+	id := v.FieldByIndex(f.Index)
+	if id.IsZero() {
+		return id, fmt.Errorf("id of model %s is empty", v.Type().Name())
+	}
+
+	return id, nil
+}
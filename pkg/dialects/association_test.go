@@ -0,0 +1,193 @@
+package dialects
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type student struct {
+	ID      uuid.UUID
+	Name    string
+	Courses []course `tinyorm:"many2many"`
+}
+
+type course struct {
+	ID       uuid.UUID
+	Title    string
+	Students []*student `tinyorm:"many2many=student_courses,join_foreign_key=course_id,join_references=student_id"`
+}
+
+func courseTitles(courses []course) []string {
+	var titles []string
+	for _, c := range courses {
+		titles = append(titles, c.Title)
+	}
+	sort.Strings(titles)
+
+	return titles
+}
+
+func TestAssociation(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&student{}, &course{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	carl, bob := &student{ID: uuid.New(), Name: "Carl"}, &student{ID: uuid.New(), Name: "Bob"}
+	math, art, music := &course{ID: uuid.New(), Title: "Math"}, &course{ID: uuid.New(), Title: "Art"}, &course{ID: uuid.New(), Title: "Music"}
+
+	for _, m := range []any{carl, bob, math, art, music} {
+		if _, err := db.Create(m); err != nil {
+			t.Fatalf("error creating model. error: %v", err.Error())
+		}
+	}
+
+	if err := db.Association(carl, "Courses").Append(math, art); err != nil {
+		t.Fatalf("error appending courses. error: %v", err.Error())
+	}
+
+	// Appending an existing association is skipped
+	if err := db.Association(carl, "Courses").Append(math); err != nil {
+		t.Fatalf("error appending courses. error: %v", err.Error())
+	}
+
+	if err := db.Association(bob, "Courses").Append(math); err != nil {
+		t.Fatalf("error appending courses. error: %v", err.Error())
+	}
+
+	if len(carl.Courses) != 2 {
+		t.Fatalf("Wanted: 2 courses - Have: %d", len(carl.Courses))
+	}
+
+	tests := map[string]struct {
+		alter func() error
+		carl  []string
+		bob   []string
+	}{
+		"preload appended": {
+			alter: func() error { return nil },
+			carl:  []string{"Art", "Math"},
+			bob:   []string{"Math"},
+		},
+		"remove": {
+			alter: func() error { return db.Association(carl, "Courses").Remove(art) },
+			carl:  []string{"Math"},
+			bob:   []string{"Math"},
+		},
+		"replace": {
+			alter: func() error { return db.Association(carl, "Courses").Replace(art, music) },
+			carl:  []string{"Art", "Music"},
+			bob:   []string{"Math"},
+		},
+		"clear": {
+			alter: func() error { return db.Association(bob, "Courses").Clear() },
+			carl:  []string{"Art", "Music"},
+			bob:   nil,
+		},
+	}
+
+	for _, name := range []string{"preload appended", "remove", "replace", "clear"} {
+		test := tests[name]
+		t.Run(name, func(t *testing.T) {
+			if err := test.alter(); err != nil {
+				t.Fatalf("error altering association. error: %v", err.Error())
+			}
+
+			students := new([]student)
+			if err := db.Model(students).Order("name asc").Preload("Courses").All(); err != nil {
+				t.Fatalf("error preloading students. error: %v", err.Error())
+			}
+
+			b, c := (*students)[0], (*students)[1]
+			if got := courseTitles(c.Courses); !equalStrings(got, test.carl) {
+				t.Fatalf("Wanted: %v - Have: %v", test.carl, got)
+			}
+
+			if got := courseTitles(b.Courses); !equalStrings(got, test.bob) {
+				t.Fatalf("Wanted: %v - Have: %v", test.bob, got)
+			}
+		})
+	}
+
+	// The inverse side of the relation shares the join table
+	c := &course{}
+	if err := db.Model(c).Where("title = ?", "Music").Preload("Students").First(); err != nil {
+		t.Fatalf("error preloading course. error: %v", err.Error())
+	}
+
+	if len(c.Students) != 1 || c.Students[0].Name != "Carl" {
+		t.Fatalf("Wanted: [Carl] - Have: %v", c.Students)
+	}
+
+	if err := db.Association(&student{Name: "New"}, "Courses").Append(math); err == nil {
+		t.Fatal("expected an error appending to a model without an id")
+	}
+
+	if err := db.Association(carl, "Name").Append(math); err == nil {
+		t.Fatal("expected an error for an attribute without a many2many relation")
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestAssociationRemoveBatches(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&student{}, &course{}, &studentCourse{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	// More associations than parameters allowed within a single query
+	rows := sqliteMaxParameters() + 10
+
+	math := &course{ID: uuid.New(), Title: "Math"}
+	students, joins := make([]student, rows), make([]studentCourse, rows)
+	for i := range students {
+		students[i] = student{ID: uuid.New(), Name: "student"}
+		joins[i] = studentCourse{StudentID: students[i].ID, CourseID: math.ID}
+	}
+
+	if _, err := db.Create(math); err != nil {
+		t.Fatalf("error creating course. error: %v", err.Error())
+	}
+
+	for _, models := range []any{&students, &joins} {
+		if _, err := db.CreateMany(models); err != nil {
+			t.Fatalf("error creating models. error: %v", err.Error())
+		}
+	}
+
+	// Every student but the last is removed
+	var removed []any
+	for i := range students[:rows-1] {
+		removed = append(removed, &students[i])
+	}
+
+	if err := db.Association(math, "Students").Remove(removed...); err != nil {
+		t.Fatalf("error removing students. error: %v", err.Error())
+	}
+
+	remaining := []studentCourse{}
+	if err := db.Where(&remaining, "course_id = ?", 0, math.ID); err != nil {
+		t.Fatalf("error finding joins. error: %v", err.Error())
+	}
+
+	if len(remaining) != 1 || remaining[0].StudentID != students[rows-1].ID {
+		t.Fatalf("Wanted: only %v - Have: %v", students[rows-1].ID, remaining)
+	}
+}
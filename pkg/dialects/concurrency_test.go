@@ -0,0 +1,156 @@
+package dialects
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mattn/go-sqlite3"
+)
+
+// The block() SQL function of the sqlite3_block driver signals entered, then waits until release is closed
+var (
+	entered = make(chan struct{}, 1)
+	release = make(chan struct{})
+)
+
+func init() {
+	sql.Register("sqlite3_block", &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("block", func() int {
+				// Called for each row, only the first call needs to signal
+				select {
+				case entered <- struct{}{}:
+				default:
+				}
+				<-release
+
+				return 1
+			}, false)
+		},
+	})
+}
+
+// newConcurrentSQLiteHandler opens the database in WAL mode with a busy timeout so concurrent writers wait instead of failing
+func newConcurrentSQLiteHandler(t *testing.T, driver string) DialectHandler {
+	t.Helper()
+
+	db, err := sql.Open(driver, "file:"+filepath.Join(t.TempDir(), "tinyorm.db")+"?_journal_mode=WAL&_busy_timeout=10000")
+	if err != nil {
+		t.Fatalf("error opening sqlite database. error: %v", err.Error())
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := db.Exec("CREATE TABLE test_users (id TEXT PRIMARY KEY, name TEXT, age INTEGER)"); err != nil {
+		t.Fatalf("error creating test_users table. error: %v", err.Error())
+	}
+
+	handler := &SQLite{}
+	handler.SetDB(db)
+
+	return handler
+}
+
+func TestConcurrentQueriesRunInParallel(t *testing.T) {
+	db := newConcurrentSQLiteHandler(t, "sqlite3_block")
+	createTestUsers(t, db, 3)
+
+	blocked := make(chan error, 1)
+	go func() {
+		blocked <- db.Where(new(testUsers), "age >= ? AND block() = 1", 0, 0)
+	}()
+
+	select {
+	case <-entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("blocking query did not start")
+	}
+
+	// The blocking query holds its connection, other queries use the remaining connections of the pool
+	found := make(chan error, 1)
+	go func() {
+		found <- db.Find(new(testUsers))
+	}()
+
+	select {
+	case err := <-found:
+		if err != nil {
+			t.Fatalf("error finding users. error: %v", err.Error())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Find waited on the blocking query")
+	}
+
+	close(release)
+	if err := <-blocked; err != nil {
+		t.Fatalf("error finding users. error: %v", err.Error())
+	}
+}
+
+func TestConcurrentActions(t *testing.T) {
+	const workers = 8
+	const rows = 10
+
+	db := newConcurrentSQLiteHandler(t, "sqlite3")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < rows; i++ {
+				user := &testUser{ID: uuid.New(), Name: fmt.Sprintf("worker-%d", w), Age: i + 1}
+				if _, err := db.Create(user); err != nil {
+					errs <- err
+					return
+				}
+
+				user.Age = 100
+				if _, err := db.Update(user); err != nil {
+					errs <- err
+					return
+				}
+
+				if err := db.Find(&testUser{}, user.ID); err != nil {
+					errs <- err
+					return
+				}
+
+				// Every other row is deleted within a transaction
+				if i%2 == 0 {
+					if err := db.Transaction(func(tx DialectHandler) error {
+						_, err := tx.Delete(user)
+
+						return err
+					}); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}
+		}(w)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("error running concurrent actions. error: %v", err.Error())
+	}
+
+	users := new(testUsers)
+	if err := db.Where(users, "age = ?", 0, 100); err != nil {
+		t.Fatalf("error finding users. error: %v", err.Error())
+	}
+
+	if len(*users) != workers*rows/2 {
+		t.Fatalf("Wanted: %d - Have: %d", workers*rows/2, len(*users))
+	}
+}
@@ -0,0 +1,86 @@
+package dialects
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCancelledContext(t *testing.T) {
+	db := newSQLiteHandler(t)
+	user := createTestUsers(t, db, 1)[0]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := map[string]func() error{
+		"find":  func() error { return db.FindContext(ctx, new(testUsers)) },
+		"where": func() error { return db.WhereContext(ctx, new(testUsers), "age >= ?", 0, 0) },
+		"create": func() error {
+			_, err := db.CreateContext(ctx, &testUser{Name: "cancelled"})
+
+			return err
+		},
+		"create many": func() error {
+			_, err := db.CreateManyContext(ctx, &testUsers{{Name: "cancelled"}})
+
+			return err
+		},
+		"upsert": func() error {
+			_, err := db.UpsertContext(ctx, &testUser{ID: user.ID, Name: "cancelled"}, []string{"id"}, []string{"name"})
+
+			return err
+		},
+		"update": func() error {
+			_, err := db.UpdateContext(ctx, &testUser{ID: user.ID, Name: "cancelled"})
+
+			return err
+		},
+		"update map": func() error {
+			_, err := db.UpdateMapContext(ctx, &testUser{ID: user.ID}, map[string]any{"name": "cancelled"})
+
+			return err
+		},
+		"update where": func() error {
+			_, err := db.UpdateWhereContext(ctx, new(testUser), map[string]any{"name": "cancelled"}, "age >= ?", 0)
+
+			return err
+		},
+		"delete": func() error {
+			_, err := db.DeleteContext(ctx, &testUser{ID: user.ID})
+
+			return err
+		},
+		"bulk delete": func() error {
+			_, err := db.BulkDeleteContext(ctx, &testUsers{user})
+
+			return err
+		},
+		"delete where": func() error {
+			_, err := db.DeleteWhereContext(ctx, new(testUser), "age >= ?", 0)
+
+			return err
+		},
+		"exec": func() error { return db.ExecContext(ctx, "DELETE FROM test_users") },
+		"raw": func() error {
+			q, err := db.RawContext(ctx, "SELECT * FROM test_users")
+			if err != nil {
+				return err
+			}
+
+			return q.All(new(testUsers))
+		},
+	}
+
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := query(); !errors.Is(err, context.Canceled) {
+				t.Fatalf("Wanted: %v - Have: %v", context.Canceled, err)
+			}
+		})
+	}
+
+	if count := countUsers(t, db); count != 1 {
+		t.Fatalf("Wanted: 1 - Have: %d", count)
+	}
+}
@@ -0,0 +1,218 @@
+package dialects
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateMany(t *testing.T) {
+	tests := map[string]struct {
+		rows   int
+		create func(db DialectHandler, users *testUsers) (Result, error)
+	}{
+		"single batch":     {rows: 10, create: func(db DialectHandler, users *testUsers) (Result, error) { return db.CreateMany(users) }},
+		"multiple batches": {rows: sqliteMaxParameters()/3 + 100, create: func(db DialectHandler, users *testUsers) (Result, error) { return db.CreateMany(users) }},
+		"create slice":     {rows: 5, create: func(db DialectHandler, users *testUsers) (Result, error) { return db.Create(users) }},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			db := newSQLiteHandler(t)
+
+			users := make(testUsers, test.rows)
+			for i := range users {
+				users[i] = testUser{Name: fmt.Sprintf("user-%d", i), Age: i}
+			}
+			// Existing ids are kept
+			users[0].ID = uuid.New()
+			id := users[0].ID
+
+			if _, err := test.create(db, &users); err != nil {
+				t.Fatalf("error creating users. error: %v", err.Error())
+			}
+
+			if count := countUsers(t, db); count != test.rows {
+				t.Fatalf("Wanted: %d - Have: %d", test.rows, count)
+			}
+
+			if users[0].ID != id || users[test.rows-1].ID == uuid.Nil {
+				t.Fatalf("Wanted: existing and generated ids - Have: %v and %v", users[0].ID, users[test.rows-1].ID)
+			}
+
+			found := &testUser{}
+			if err := db.Find(found, users[test.rows-1].ID); err != nil {
+				t.Fatalf("error finding user. error: %v", err.Error())
+			}
+
+			if found.Age != test.rows-1 {
+				t.Fatalf("Wanted: %d - Have: %d", test.rows-1, found.Age)
+			}
+		})
+	}
+}
+
+func TestCreateManyPointers(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&hookUser{}, &post{}, &document{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	posts := []*post{{Title: "First"}, {Title: "Second"}}
+	if _, err := db.CreateMany(&posts); err != nil {
+		t.Fatalf("error creating posts. error: %v", err.Error())
+	}
+
+	for _, p := range posts {
+		if p.ID == uuid.Nil || p.CreatedAt.IsZero() || p.UpdatedAt.IsZero() {
+			t.Fatalf("Wanted: id and timestamps to be set - Have: %v", p)
+		}
+	}
+
+	docs := []document{{Title: "One"}, {Title: "Two"}}
+	if _, err := db.CreateMany(&docs); err != nil {
+		t.Fatalf("error creating documents. error: %v", err.Error())
+	}
+
+	if docs[0].Version != 1 || docs[1].Version != 1 {
+		t.Fatalf("Wanted: version 1 - Have: %d and %d", docs[0].Version, docs[1].Version)
+	}
+
+	// A failing BeforeCreate hook aborts the whole insert
+	users := []hookUser{{Name: "carl"}, {}}
+	if _, err := db.CreateMany(&users); err == nil {
+		t.Fatal("expected BeforeCreate to abort the insert")
+	}
+
+	if count := countHookUsers(t, db); count != 0 {
+		t.Fatalf("Wanted: 0 - Have: %d", count)
+	}
+
+	if _, err := db.CreateMany(&testUser{}); err == nil {
+		t.Fatal("expected an error creating a model which is not a slice")
+	}
+}
+
+type gadget struct {
+	ID    uuid.UUID
+	Name  string
+	Count int
+}
+
+func TestCreateManyDefaults(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	q, err := db.Raw("CREATE TABLE gadgets (id TEXT PRIMARY KEY, name TEXT, count INTEGER NOT NULL DEFAULT 7)")
+	if err != nil {
+		t.Fatalf("error building query. error: %v", err.Error())
+	}
+
+	if _, err := q.Exec(); err != nil {
+		t.Fatalf("error creating table. error: %v", err.Error())
+	}
+
+	// Rows with and without the count share a batch, the empty count must use the column default
+	gadgets := []gadget{{Name: "set", Count: 3}, {Name: "default"}, {Name: "also set", Count: 5}}
+	r, err := db.CreateMany(&gadgets)
+	if err != nil {
+		t.Fatalf("error creating gadgets. error: %v", err.Error())
+	}
+
+	if r.RowsAffected != 3 {
+		t.Fatalf("Wanted: 3 - Have: %d", r.RowsAffected)
+	}
+
+	for _, want := range []gadget{{Name: "set", Count: 3}, {Name: "default", Count: 7}, {Name: "also set", Count: 5}} {
+		found := []gadget{}
+		if err := db.Where(&found, "name = ?", 0, want.Name); err != nil {
+			t.Fatalf("error finding gadget. error: %v", err.Error())
+		}
+
+		if len(found) != 1 || found[0].Count != want.Count {
+			t.Fatalf("Wanted: %v - Have: %v", want, found)
+		}
+	}
+}
+
+type widget struct {
+	ID     int64
+	Name   string
+	Status string
+}
+
+func TestCreateReadsGeneratedColumns(t *testing.T) {
+	tests := map[string]struct {
+		create func(db DialectHandler, w *widget) (Result, error)
+	}{
+		"returning": {create: func(db DialectHandler, w *widget) (Result, error) { return db.Create(w) }},
+		// MySQL does not support RETURNING, the row is selected again by its id. The MySQL statements are valid SQLite.
+		"reselect": {create: func(db DialectHandler, w *widget) (Result, error) {
+			return Create(context.Background(), db.(*SQLite).db, w, DIALECT_TYPE_MYSQL)
+		}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			db := newSQLiteHandler(t)
+			if err := db.Exec("CREATE TABLE widgets (id INTEGER PRIMARY KEY, name TEXT, status TEXT DEFAULT 'new')"); err != nil {
+				t.Fatalf("error creating widgets table. error: %v", err.Error())
+			}
+
+			for i := 1; i <= 2; i++ {
+				w := &widget{Name: fmt.Sprintf("widget-%d", i)}
+				result, err := test.create(db, w)
+				if err != nil {
+					t.Fatalf("error creating widget. error: %v", err.Error())
+				}
+
+				if w.ID != int64(i) || result.LastInsertId != int64(i) || w.Status != "new" {
+					t.Fatalf("Wanted: id %d with status new - Have: %+v %+v", i, *w, result)
+				}
+			}
+		})
+	}
+}
+
+type note struct {
+	ID   string
+	Body string
+}
+
+func TestCreateGeneratesIDs(t *testing.T) {
+	tests := map[string]func(db DialectHandler, model any) (Result, error){
+		"returning": func(db DialectHandler, model any) (Result, error) { return db.Create(model) },
+		"reselect": func(db DialectHandler, model any) (Result, error) {
+			return Create(context.Background(), db.(*SQLite).db, model, DIALECT_TYPE_MYSQL)
+		},
+	}
+
+	for name, create := range tests {
+		t.Run(name, func(t *testing.T) {
+			db := newSQLiteHandler(t)
+			if err := db.AutoMigrate(&note{}); err != nil {
+				t.Fatalf("error migrating models. error: %v", err.Error())
+			}
+
+			user := &testUser{Name: "carl"}
+			if _, err := create(db, user); err != nil {
+				t.Fatalf("error creating user. error: %v", err.Error())
+			}
+
+			if user.ID == uuid.Nil {
+				t.Fatal("Wanted: a generated uuid - Have: an empty id")
+			}
+
+			n := &note{Body: "first"}
+			if _, err := create(db, n); err != nil {
+				t.Fatalf("error creating note. error: %v", err.Error())
+			}
+
+			if _, err := uuid.Parse(n.ID); err != nil {
+				t.Fatalf("Wanted: a generated uuid string - Have: %q", n.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package dialects
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func createTestUsers(t testing.TB, db DialectHandler, n int) testUsers {
+	t.Helper()
+
+	users := make(testUsers, n)
+	for i := range users {
+		users[i] = testUser{ID: uuid.New(), Name: fmt.Sprintf("user-%d", i), Age: i}
+	}
+
+	if _, err := db.CreateMany(&users); err != nil {
+		t.Fatalf("error creating users. error: %v", err.Error())
+	}
+
+	return users
+}
+
+func TestBulkDelete(t *testing.T) {
+	tests := map[string]struct {
+		rows   int
+		delete func(users testUsers) testUsers
+	}{
+		"subset":           {rows: 10, delete: func(users testUsers) testUsers { return users[2:5] }},
+		"empty slice":      {rows: 3, delete: func(users testUsers) testUsers { return testUsers{} }},
+		"multiple batches": {rows: sqliteMaxParameters() + 10, delete: func(users testUsers) testUsers { return users[5:] }},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			db := newSQLiteHandler(t)
+			users := createTestUsers(t, db, test.rows)
+			remove := test.delete(users)
+
+			deleted, err := db.BulkDelete(&remove)
+			if err != nil {
+				t.Fatalf("error deleting users. error: %v", err.Error())
+			}
+
+			if deleted != int64(len(remove)) {
+				t.Fatalf("Wanted: %d deleted - Have: %d", len(remove), deleted)
+			}
+
+			if count := countUsers(t, db); count != test.rows-len(remove) {
+				t.Fatalf("Wanted: %d - Have: %d", test.rows-len(remove), count)
+			}
+		})
+	}
+
+	t.Run("missing id", func(t *testing.T) {
+		db := newSQLiteHandler(t)
+		users := createTestUsers(t, db, 2)
+		users = append(users, testUser{Name: "no id"})
+
+		if _, err := db.BulkDelete(&users); err == nil {
+			t.Fatal("expected an error deleting a model without an id")
+		}
+
+		if count := countUsers(t, db); count != 2 {
+			t.Fatalf("Wanted: 2 - Have: %d", count)
+		}
+	})
+
+	t.Run("soft delete", func(t *testing.T) {
+		db := newSQLiteHandler(t)
+		if err := db.AutoMigrate(&account{}); err != nil {
+			t.Fatalf("error migrating models. error: %v", err.Error())
+		}
+
+		accounts := []account{{ID: uuid.New(), Name: "Carl"}, {ID: uuid.New(), Name: "Bob"}}
+		if _, err := db.CreateMany(&accounts); err != nil {
+			t.Fatalf("error creating accounts. error: %v", err.Error())
+		}
+
+		remove := accounts[:1]
+		if deleted, err := db.BulkDelete(&remove); err != nil || deleted != 1 {
+			t.Fatalf("Wanted: 1 deleted - Have: %d. error: %v", deleted, err)
+		}
+
+		if remove[0].DeletedAt == nil {
+			t.Fatal("DeletedAt was not set on the model")
+		}
+
+		if count, _ := db.Model(new([]account)).Count(); count != 1 {
+			t.Fatalf("Wanted: 1 - Have: %d", count)
+		}
+	})
+}
+
+func TestDeleteAll(t *testing.T) {
+	db := newSQLiteHandler(t)
+	createTestUsers(t, db, 5)
+
+	if _, err := db.DeleteAll(new(testUsers), ""); err == nil {
+		t.Fatal("expected an error deleting all rows without confirmation")
+	}
+
+	deleted, err := db.DeleteAll(new(testUsers), ConfirmAll)
+	if err != nil {
+		t.Fatalf("error deleting users. error: %v", err.Error())
+	}
+
+	if deleted != 5 {
+		t.Fatalf("Wanted: 5 deleted - Have: %d", deleted)
+	}
+
+	if count := countUsers(t, db); count != 0 {
+		t.Fatalf("Wanted: 0 - Have: %d", count)
+	}
+}
+
+func TestDeleteWhere(t *testing.T) {
+	db := newSQLiteHandler(t)
+	createTestUsers(t, db, 10)
+
+	deleted, err := db.DeleteWhere(&testUser{}, "age > ? OR name = ?", 6, "user-0")
+	if err != nil {
+		t.Fatalf("error deleting users. error: %v", err.Error())
+	}
+
+	if deleted != 4 {
+		t.Fatalf("Wanted: 4 deleted - Have: %d", deleted)
+	}
+
+	if count := countUsers(t, db); count != 6 {
+		t.Fatalf("Wanted: 6 - Have: %d", count)
+	}
+
+	if _, err := db.DeleteWhere(&testUser{}, ""); err == nil {
+		t.Fatal("expected an error for an empty statement")
+	}
+
+	t.Run("soft delete", func(t *testing.T) {
+		if err := db.AutoMigrate(&account{}); err != nil {
+			t.Fatalf("error migrating models. error: %v", err.Error())
+		}
+
+		accounts := []account{{ID: uuid.New(), Name: "Carl"}, {ID: uuid.New(), Name: "Bob"}}
+		if _, err := db.CreateMany(&accounts); err != nil {
+			t.Fatalf("error creating accounts. error: %v", err.Error())
+		}
+
+		for i := 0; i < 2; i++ {
+			deleted, err := db.DeleteWhere(&account{}, "name = ?", "Carl")
+			if err != nil {
+				t.Fatalf("error deleting accounts. error: %v", err.Error())
+			}
+
+			// Rows already soft deleted are not deleted again
+			if deleted != int64(1-i) {
+				t.Fatalf("Wanted: %d deleted - Have: %d", 1-i, deleted)
+			}
+		}
+
+		count, err := db.Model(new([]account)).Unscoped().Count()
+		if err != nil {
+			t.Fatalf("error counting accounts. error: %v", err.Error())
+		}
+
+		if count != 2 {
+			t.Fatalf("Wanted: 2 - Have: %d", count)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package dialects
+
+import (
+	"testing"
+)
+
+// BenchmarkFind scans a large table into a slice, measuring the allocations per Find
+func BenchmarkFind(b *testing.B) {
+	db := newSQLiteHandler(b)
+	createTestUsers(b, db, 10000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		users := new(testUsers)
+		if err := db.Find(users); err != nil {
+			b.Fatalf("error finding users. error: %v", err.Error())
+		}
+	}
+}
+
+// BenchmarkWhere scans the rows matching the condition into a slice
+func BenchmarkWhere(b *testing.B) {
+	db := newSQLiteHandler(b)
+	createTestUsers(b, db, 10000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		users := new(testUsers)
+		if err := db.Where(users, "age > ?", 0, 5000); err != nil {
+			b.Fatalf("error finding users. error: %v", err.Error())
+		}
+	}
+}
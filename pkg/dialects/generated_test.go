@@ -0,0 +1,158 @@
+package dialects
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
+	"github.com/google/uuid"
+)
+
+//go:generate go run ../../cmd/tinyorm gen -type genUser
+
+// genUser is scanned and bound using the methods generated by tinyorm gen
+type genUser struct {
+	ID        uuid.UUID
+	Name      string
+	Age       int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type genUsers []genUser
+
+// staleUser has methods which no longer match its attributes and so is reflected
+type staleUser struct {
+	ID   uuid.UUID
+	Name string
+	Age  int
+}
+
+func (m *staleUser) TinyColumns() []string { return []string{"id", "name"} }
+func (m *staleUser) TinyScanDest() []any   { return []any{&m.ID, &m.Name} }
+func (m *staleUser) TinyValues() []any     { return []any{m.ID, m.Name} }
+
+func TestGeneratedModel(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&genUser{}, &staleUser{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	t.Run("generated methods are used", func(t *testing.T) {
+		if !sqlbuilder.ModelInfoOf(reflect.TypeOf(genUser{})).Generated {
+			t.Fatal("Wanted: generated - Have: reflected")
+		}
+
+		if sqlbuilder.ModelInfoOf(reflect.TypeOf(staleUser{})).Generated {
+			t.Fatal("Wanted: stale methods ignored - Have: generated")
+		}
+	})
+
+	t.Run("create and find", func(t *testing.T) {
+		users := genUsers{{Name: "carl", Age: 30}, {Name: "bob", Age: 40}}
+		if _, err := db.CreateMany(&users); err != nil {
+			t.Fatalf("error creating users. error: %v", err.Error())
+		}
+
+		user := &genUser{ID: uuid.New(), Name: "alice", Age: 50}
+		if _, err := db.Create(user); err != nil {
+			t.Fatalf("error creating user. error: %v", err.Error())
+		}
+
+		found := new(genUsers)
+		if err := db.Find(found); err != nil {
+			t.Fatalf("error finding users. error: %v", err.Error())
+		}
+
+		if len(*found) != 3 || (*found)[2].Name != "alice" || (*found)[2].Age != 50 || (*found)[2].CreatedAt.IsZero() {
+			t.Fatalf("Wanted: 3 users ending with alice - Have: %+v", *found)
+		}
+
+		single := &genUser{}
+		if err := db.Find(single, user.ID); err != nil {
+			t.Fatalf("error finding user. error: %v", err.Error())
+		}
+
+		if single.Name != "alice" {
+			t.Fatalf("Wanted: alice - Have: %v", single.Name)
+		}
+	})
+
+	t.Run("update and where", func(t *testing.T) {
+		older := new(genUsers)
+		if err := db.Where(older, "age > ?", 0, 35); err != nil {
+			t.Fatalf("error finding users. error: %v", err.Error())
+		}
+
+		for _, u := range *older {
+			u.Age = 0
+			if _, err := db.UpdateAll(&u); err != nil {
+				t.Fatalf("error updating user. error: %v", err.Error())
+			}
+		}
+
+		reset := new(genUsers)
+		if err := db.Where(reset, "age = ?", 0, 0); err != nil {
+			t.Fatalf("error finding users. error: %v", err.Error())
+		}
+
+		if len(*reset) != 2 {
+			t.Fatalf("Wanted: 2 - Have: %d", len(*reset))
+		}
+	})
+
+	t.Run("stale methods fall back to reflection", func(t *testing.T) {
+		user := &staleUser{ID: uuid.New(), Name: "carl", Age: 30}
+		if _, err := db.Create(user); err != nil {
+			t.Fatalf("error creating user. error: %v", err.Error())
+		}
+
+		found := &staleUser{}
+		if err := db.Find(found, user.ID); err != nil {
+			t.Fatalf("error finding user. error: %v", err.Error())
+		}
+
+		if found.Age != 30 {
+			t.Fatalf("Wanted: 30 - Have: %d", found.Age)
+		}
+	})
+}
+
+// reflectedUser has the attributes of genUser without generated methods
+type reflectedUser genUser
+
+// BenchmarkFindGenerated performs the same as BenchmarkFindReflected using a model with generated methods
+func BenchmarkFindGenerated(b *testing.B) {
+	benchmarkFindUsers[genUser](b)
+}
+
+func BenchmarkFindReflected(b *testing.B) {
+	benchmarkFindUsers[reflectedUser](b)
+}
+
+func benchmarkFindUsers[T genUser | reflectedUser](b *testing.B) {
+	db := newSQLiteHandler(b)
+	if err := db.AutoMigrate(new(T)); err != nil {
+		b.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	users := make([]T, 10000)
+	for i := range users {
+		users[i] = T{Name: "user", Age: i + 1}
+	}
+
+	if _, err := db.CreateMany(&users); err != nil {
+		b.Fatalf("error creating users. error: %v", err.Error())
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		found := new([]T)
+		if err := db.Find(found); err != nil {
+			b.Fatalf("error finding users. error: %v", err.Error())
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Code generated by tinyorm gen. DO NOT EDIT.
+
+package dialects
+
+import "github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
+
+var tinyGenUserColumns = []string{"id", "name", "age", "created_at", "updated_at"}
+
+// TinyColumns returns the columns of genUser in the order of the model attributes. The returned slice must not be modified.
+func (m *genUser) TinyColumns() []string {
+	return tinyGenUserColumns
+}
+
+// TinyScanDest returns the pointers scanned into for each column of genUser
+func (m *genUser) TinyScanDest() []any {
+	return []any{&m.ID, &m.Name, &m.Age, sqlbuilder.ScanTime(&m.CreatedAt), sqlbuilder.ScanTime(&m.UpdatedAt)}
+}
+
+// TinyValues returns the values of each column of genUser
+func (m *genUser) TinyValues() []any {
+	return []any{m.ID, m.Name, m.Age, m.CreatedAt, m.UpdatedAt}
+}
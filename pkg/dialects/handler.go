@@ -1,33 +1,78 @@
 package dialects
 
 import (
+	"context"
 	"database/sql"
 	"time"
 )
 
-var Databases map[string]DialectHandler
+// Databases maps the dialect within the database.yml to the constructor of its handler, each connection is given a new handler
+var Databases map[string]func() DialectHandler
 
 func init() {
-	Databases = map[string]DialectHandler{
-		"mysql":    &Mysql{},
-		"postgres": &Postgres{},
-		"sqlite3":  &SQLite{},
+	Databases = map[string]func() DialectHandler{
+		"mysql":    func() DialectHandler { return &Mysql{} },
+		"postgres": func() DialectHandler { return &Postgres{} },
+		"sqlite3":  func() DialectHandler { return &SQLite{} },
 	}
 }
 
 // DialectHandler is the primary interface that all database types must comply too
+// Each action has a Context variant, the context is passed through to the underlying database/sql calls
+// Handlers are safe for concurrent use, concurrent actions run in parallel using the connection pool of the *sql.DB.
+// Handlers returned by Begin are bound to a single transaction, the statements of a transaction run on one connection one at a time.
 type DialectHandler interface {
-	Create(model any) error
-	Update(model any) error
-	Delete(model any) error
-	BulkDelete(model any) error
+	Create(model any) (Result, error)
+	CreateContext(ctx context.Context, model any) (Result, error)
+	CreateMany(model any) (Result, error)
+	CreateManyContext(ctx context.Context, model any) (Result, error)
+	Upsert(model any, conflictColumns []string, updateColumns []string) (Result, error)
+	UpsertContext(ctx context.Context, model any, conflictColumns []string, updateColumns []string) (Result, error)
+	Update(model any, opts ...Option) (Result, error)
+	UpdateContext(ctx context.Context, model any, opts ...Option) (Result, error)
+	UpdateAll(model any) (Result, error)
+	UpdateAllContext(ctx context.Context, model any) (Result, error)
+	UpdateMap(model any, values map[string]any) (Result, error)
+	UpdateMapContext(ctx context.Context, model any, values map[string]any) (Result, error)
+	UpdateWhere(model any, values map[string]any, stmt string, args ...any) (int64, error)
+	UpdateWhereContext(ctx context.Context, model any, values map[string]any, stmt string, args ...any) (int64, error)
+	Delete(model any) (Result, error)
+	DeleteContext(ctx context.Context, model any) (Result, error)
+	HardDelete(model any) (Result, error)
+	HardDeleteContext(ctx context.Context, model any) (Result, error)
+	BulkDelete(model any) (int64, error)
+	BulkDeleteContext(ctx context.Context, model any) (int64, error)
+	DeleteAll(model any, confirm Confirmation) (int64, error)
+	DeleteAllContext(ctx context.Context, model any, confirm Confirmation) (int64, error)
+	DeleteWhere(model any, stmt string, args ...any) (int64, error)
+	DeleteWhereContext(ctx context.Context, model any, stmt string, args ...any) (int64, error)
 	Where(model any, stmt string, limit int, args ...any) error
+	WhereContext(ctx context.Context, model any, stmt string, limit int, args ...any) error
 	Find(model any, args ...any) error
+	FindContext(ctx context.Context, model any, args ...any) error
 	Raw(query string, args ...any) (*RawQuery, error)
+	RawContext(ctx context.Context, query string, args ...any) (*RawQuery, error)
+	Exec(query string, args ...any) error
+	ExecContext(ctx context.Context, query string, args ...any) error
+	Model(model any) *ModelQuery
+	Association(model any, name string) *Association
+	AutoMigrate(models ...any) error
+	AutoMigrateContext(ctx context.Context, models ...any) error
+	TableColumns(tableName string) (map[string]string, error)
+	TableColumnsContext(ctx context.Context, tableName string) (map[string]string, error)
+	Begin() (DialectHandler, error)
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (DialectHandler, error)
+	Commit() error
+	Rollback() error
+	Transaction(fn func(tx DialectHandler) error) error
+	TransactionContext(ctx context.Context, fn func(tx DialectHandler) error) error
 	SetDB(connDB *sql.DB)
+	Close() error
+	CloseContext(ctx context.Context) error
 	QueryString() string
 	SetConfig(config DBConfig)
 	GetConfig() DBConfig
+	Dialect() string
 }
 
 type DBConfig struct {
@@ -45,6 +90,8 @@ type DBConfig struct {
 	MaxLifetime time.Duration `yaml:"maxLifetime,omitempty"`
 	MaxIdleConn int           `yaml:"maxIdleConn,omitempty"`
 	MaxOpenConn int           `yaml:"maxOpenConn,omitempty"`
+	// Amount of prepared statements cached by the handler, defaults to DEFAULT_STATEMENT_CACHE_SIZE. A negative size disables the cache.
+	StatementCacheSize int `yaml:"statementCacheSize,omitempty"`
 }
 
 type MultiTenantDialectHandler struct {
@@ -61,6 +108,19 @@ func (mtd MultiTenantDialectHandler) Empty() bool {
 	return len(mtd.Handlers) == 0
 }
 
+// Close closes every database handler, waiting up to DEFAULT_CLOSE_TIMEOUT for the in-flight queries of each handler to finish
+func (mtd MultiTenantDialectHandler) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_CLOSE_TIMEOUT)
+	defer cancel()
+
+	return mtd.CloseContext(ctx)
+}
+
+// CloseContext performs the same as Close, waiting for in-flight queries until the context is done
+func (mtd MultiTenantDialectHandler) CloseContext(ctx context.Context) error {
+	return CloseHandlers(ctx, mtd.Handlers)
+}
+
 // Switch allows the caller to alter to different databases to perform executions again
 func (mtd MultiTenantDialectHandler) SwitchDB(database string) DialectHandler {
 	if db, found := mtd.Handlers[database]; found {
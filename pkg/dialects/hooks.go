@@ -0,0 +1,139 @@
+package dialects
+
+import (
+	"context"
+	"reflect"
+)
+
+// Models may implement any of the hook interfaces below, the hooks are called around the SQL of the matching action.
+// A Before hook returning an error aborts the action before any SQL is executed.
+// An After hook returning an error is returned from the action, the SQL has already been executed at that point.
+// Slices of models call the hook for each model within the slice.
+type BeforeCreateHook interface {
+	BeforeCreate(ctx context.Context) error
+}
+
+type AfterCreateHook interface {
+	AfterCreate(ctx context.Context) error
+}
+
+type BeforeUpdateHook interface {
+	BeforeUpdate(ctx context.Context) error
+}
+
+type AfterUpdateHook interface {
+	AfterUpdate(ctx context.Context) error
+}
+
+type BeforeDeleteHook interface {
+	BeforeDelete(ctx context.Context) error
+}
+
+type AfterDeleteHook interface {
+	AfterDelete(ctx context.Context) error
+}
+
+// AfterFind is called for each model found by Find, Where and the query builder
+type AfterFindHook interface {
+	AfterFind(ctx context.Context) error
+}
+
+func beforeCreate(ctx context.Context, model any) error {
+	return eachModel(model, func(m any) error {
+		if h, ok := m.(BeforeCreateHook); ok {
+			return h.BeforeCreate(ctx)
+		}
+
+		return nil
+	})
+}
+
+func afterCreate(ctx context.Context, model any) error {
+	return eachModel(model, func(m any) error {
+		if h, ok := m.(AfterCreateHook); ok {
+			return h.AfterCreate(ctx)
+		}
+
+		return nil
+	})
+}
+
+func beforeUpdate(ctx context.Context, model any) error {
+	return eachModel(model, func(m any) error {
+		if h, ok := m.(BeforeUpdateHook); ok {
+			return h.BeforeUpdate(ctx)
+		}
+
+		return nil
+	})
+}
+
+func afterUpdate(ctx context.Context, model any) error {
+	return eachModel(model, func(m any) error {
+		if h, ok := m.(AfterUpdateHook); ok {
+			return h.AfterUpdate(ctx)
+		}
+
+		return nil
+	})
+}
+
+func beforeDelete(ctx context.Context, model any) error {
+	return eachModel(model, func(m any) error {
+		if h, ok := m.(BeforeDeleteHook); ok {
+			return h.BeforeDelete(ctx)
+		}
+
+		return nil
+	})
+}
+
+func afterDelete(ctx context.Context, model any) error {
+	return eachModel(model, func(m any) error {
+		if h, ok := m.(AfterDeleteHook); ok {
+			return h.AfterDelete(ctx)
+		}
+
+		return nil
+	})
+}
+
+func afterFind(ctx context.Context, model any) error {
+	return eachModel(model, func(m any) error {
+		if h, ok := m.(AfterFindHook); ok {
+			return h.AfterFind(ctx)
+		}
+
+		return nil
+	})
+}
+
+// Calls fn with a pointer to the model, or with a pointer to each model when the model is a slice.
+// Pointers are passed so hooks declared on pointer receivers are found and may alter the model.
+func eachModel(model any, fn func(m any) error) error {
+	value := reflect.Indirect(reflect.ValueOf(model))
+	if value.Kind() != reflect.Slice {
+		if value.CanAddr() {
+			return fn(value.Addr().Interface())
+		}
+
+		return fn(model)
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		elem := value.Index(i)
+		if elem.Kind() != reflect.Ptr {
+			elem = elem.Addr()
+		}
+
+		if elem.IsNil() {
+			continue
+		}
+
+		if err := fn(elem.Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package dialects
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var errInvalidName = errors.New("name cannot be empty")
+
+type hookUser struct {
+	ID    uuid.UUID
+	Name  string
+	Calls []string `db:"-"`
+}
+
+// Sets a default id and validates the name
+func (h *hookUser) BeforeCreate(ctx context.Context) error {
+	if h.Name == "" {
+		return errInvalidName
+	}
+
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	h.Calls = append(h.Calls, "BeforeCreate")
+
+	return nil
+}
+
+func (h *hookUser) AfterCreate(ctx context.Context) error {
+	h.Calls = append(h.Calls, "AfterCreate")
+
+	return nil
+}
+
+func (h *hookUser) BeforeUpdate(ctx context.Context) error {
+	h.Name = strings.TrimSpace(h.Name)
+	h.Calls = append(h.Calls, "BeforeUpdate")
+
+	return nil
+}
+
+func (h *hookUser) AfterUpdate(ctx context.Context) error {
+	h.Calls = append(h.Calls, "AfterUpdate")
+
+	return nil
+}
+
+func (h *hookUser) BeforeDelete(ctx context.Context) error {
+	if h.Name == "admin" {
+		return errors.New("admin cannot be deleted")
+	}
+	h.Calls = append(h.Calls, "BeforeDelete")
+
+	return nil
+}
+
+func (h *hookUser) AfterDelete(ctx context.Context) error {
+	h.Calls = append(h.Calls, "AfterDelete")
+
+	return nil
+}
+
+func (h *hookUser) AfterFind(ctx context.Context) error {
+	h.Name = strings.ToUpper(h.Name)
+
+	return nil
+}
+
+func countHookUsers(t *testing.T, db DialectHandler) int64 {
+	t.Helper()
+
+	count, err := db.Model(new([]hookUser)).Count()
+	if err != nil {
+		t.Fatalf("error counting hook users. error: %v", err.Error())
+	}
+
+	return count
+}
+
+func TestHooks(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&hookUser{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	t.Run("before create aborts", func(t *testing.T) {
+		if _, err := db.Create(&hookUser{}); !errors.Is(err, errInvalidName) {
+			t.Fatalf("Wanted: %v - Have: %v", errInvalidName, err)
+		}
+
+		if count := countHookUsers(t, db); count != 0 {
+			t.Fatalf("Wanted: 0 - Have: %d", count)
+		}
+	})
+
+	user := &hookUser{Name: "carl"}
+	admin := &hookUser{Name: "admin"}
+
+	t.Run("create", func(t *testing.T) {
+		for _, u := range []*hookUser{user, admin} {
+			if _, err := db.Create(u); err != nil {
+				t.Fatalf("error creating user. error: %v", err.Error())
+			}
+		}
+
+		if user.ID == uuid.Nil {
+			t.Fatal("BeforeCreate did not set the id")
+		}
+
+		if strings.Join(user.Calls, ",") != "BeforeCreate,AfterCreate" {
+			t.Fatalf("Wanted: BeforeCreate,AfterCreate - Have: %v", user.Calls)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		user.Name, user.Calls = "  bob  ", nil
+		if _, err := db.Update(user); err != nil {
+			t.Fatalf("error updating user. error: %v", err.Error())
+		}
+
+		if strings.Join(user.Calls, ",") != "BeforeUpdate,AfterUpdate" {
+			t.Fatalf("Wanted: BeforeUpdate,AfterUpdate - Have: %v", user.Calls)
+		}
+	})
+
+	t.Run("after find", func(t *testing.T) {
+		found := &hookUser{}
+		if err := db.Find(found, user.ID); err != nil {
+			t.Fatalf("error finding user. error: %v", err.Error())
+		}
+
+		if found.Name != "BOB" {
+			t.Fatalf("Wanted: BOB - Have: %v", found.Name)
+		}
+
+		users := new([]hookUser)
+		if err := db.Where(users, "name = ?", 0, "admin"); err != nil {
+			t.Fatalf("error finding users. error: %v", err.Error())
+		}
+
+		if len(*users) != 1 || (*users)[0].Name != "ADMIN" {
+			t.Fatalf("Wanted: [ADMIN] - Have: %v", *users)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if _, err := db.Delete(admin); err == nil {
+			t.Fatal("expected BeforeDelete to abort the delete")
+		}
+
+		user.Calls = nil
+		if _, err := db.Delete(user); err != nil {
+			t.Fatalf("error deleting user. error: %v", err.Error())
+		}
+
+		if strings.Join(user.Calls, ",") != "BeforeDelete,AfterDelete" {
+			t.Fatalf("Wanted: BeforeDelete,AfterDelete - Have: %v", user.Calls)
+		}
+
+		if count := countHookUsers(t, db); count != 1 {
+			t.Fatalf("Wanted: 1 - Have: %d", count)
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package dialects
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Amount of time Close waits for in-flight queries and transactions to finish
+const DEFAULT_CLOSE_TIMEOUT = 30 * time.Second
+
+// Interval between checks of the connections in use whilst draining
+const drainInterval = 10 * time.Millisecond
+
+// ErrCloseTimeout is returned by Close when queries were still in-flight once the timeout passed.
+// The connections of the in-flight queries are closed as each query finishes.
+var ErrCloseTimeout = errors.New("timed out waiting for in-flight queries to finish")
+
+var (
+	closeHooks   []func(handler DialectHandler)
+	closeHooksMu sync.RWMutex
+)
+
+// OnClose registers fn to be called with every handler once it has been closed.
+// Used by the connections package to remove closed handlers from the open connections.
+func OnClose(fn func(handler DialectHandler)) {
+	closeHooksMu.Lock()
+	defer closeHooksMu.Unlock()
+
+	closeHooks = append(closeHooks, fn)
+}
+
+func runCloseHooks(handler DialectHandler) {
+	closeHooksMu.RLock()
+	defer closeHooksMu.RUnlock()
+
+	for _, fn := range closeHooks {
+		fn(handler)
+	}
+}
+
+// Closes the database connection pool of a handler, then waits for the in-flight queries to finish before closing the cached statements.
+// Closing the pool prevents new queries from starting, in-flight queries and transactions keep their connection until finished.
+// The close hooks are run once the pool is closed, even when draining times out.
+func closeHandler(ctx context.Context, handler DialectHandler, db *sql.DB, tx *sql.Tx, stmts *stmtCache) error {
+	if tx != nil {
+		return errors.New("cannot close a handler within a transaction, call Commit or Rollback")
+	}
+	defer runCloseHooks(handler)
+
+	var err error
+	if db != nil {
+		if err = db.Close(); err == nil {
+			err = drain(ctx, db)
+		}
+	}
+
+	// Once drained no rows of the statements are open, so the statements can be closed before returning
+	if stmts != nil {
+		stmts.close(err == nil)
+	}
+
+	return err
+}
+
+// Waits until no connections of the closed pool are in use or the context is done
+func drain(ctx context.Context, db *sql.DB) error {
+	ticker := time.NewTicker(drainInterval)
+	defer ticker.Stop()
+
+	for db.Stats().InUse > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w, %d connections in use. Error: %v", ErrCloseTimeout, db.Stats().InUse, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+
+	return nil
+}
+
+// Closes the handler with the default timeout
+func closeWithTimeout(handler DialectHandler) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_CLOSE_TIMEOUT)
+	defer cancel()
+
+	return handler.CloseContext(ctx)
+}
+
+// CloseHandlers closes each of the handlers concurrently, so every handler drains within the same timeout.
+// The errors of the handlers which failed to close are returned together.
+func CloseHandlers(ctx context.Context, handlers map[string]DialectHandler) error {
+	errs := make(chan string, len(handlers))
+
+	for name, handler := range handlers {
+		go func(name string, handler DialectHandler) {
+			if err := handler.CloseContext(ctx); err != nil {
+				errs <- fmt.Sprintf("%s: %v", name, err.Error())
+
+				return
+			}
+			errs <- ""
+		}(name, handler)
+	}
+
+	var failed []string
+	for range handlers {
+		if err := <-errs; err != "" {
+			failed = append(failed, err)
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	sort.Strings(failed)
+
+	return fmt.Errorf("error closing database connections. %s", strings.Join(failed, "; "))
+}
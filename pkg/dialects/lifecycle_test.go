@@ -0,0 +1,150 @@
+package dialects
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// The wait(key) SQL function of the sqlite3_wait driver signals the entered channel of the key, then waits until its release channel is closed
+var waits sync.Map
+
+type waitChannels struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func init() {
+	sql.Register("sqlite3_wait", &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("wait", func(key string) int {
+				w, _ := waits.Load(key)
+				select {
+				case w.(*waitChannels).entered <- struct{}{}:
+				default:
+				}
+				<-w.(*waitChannels).release
+
+				return 1
+			}, false)
+		},
+	})
+}
+
+// Starts a query which waits until the returned release function is called
+func startWaitingQuery(t *testing.T, db DialectHandler, key string) (func(), <-chan error) {
+	t.Helper()
+
+	w := &waitChannels{entered: make(chan struct{}, 1), release: make(chan struct{})}
+	waits.Store(key, w)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- db.Where(new(testUsers), "age >= ? AND wait(?) = 1", 0, 0, key)
+	}()
+
+	select {
+	case <-w.entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiting query did not start")
+	}
+
+	return func() { close(w.release) }, done
+}
+
+func TestClose(t *testing.T) {
+	t.Run("drains in-flight queries", func(t *testing.T) {
+		db := newConcurrentSQLiteHandler(t, "sqlite3_wait")
+		createTestUsers(t, db, 3)
+
+		release, done := startWaitingQuery(t, db, "drain")
+
+		closed := make(chan error, 1)
+		go func() { closed <- db.Close() }()
+
+		select {
+		case err := <-closed:
+			t.Fatalf("Wanted: Close to wait on the in-flight query - Have: closed with %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		if err := db.Find(new(testUsers)); err == nil {
+			t.Fatal("expected an error starting a query on a closing handler")
+		}
+
+		release()
+		if err := <-done; err != nil {
+			t.Fatalf("error finishing in-flight query. error: %v", err.Error())
+		}
+
+		if err := <-closed; err != nil {
+			t.Fatalf("error closing handler. error: %v", err.Error())
+		}
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		db := newConcurrentSQLiteHandler(t, "sqlite3_wait")
+		createTestUsers(t, db, 3)
+
+		release, done := startWaitingQuery(t, db, "timeout")
+		defer func() {
+			release()
+			<-done
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		if err := db.CloseContext(ctx); !errors.Is(err, ErrCloseTimeout) {
+			t.Fatalf("Wanted: %v - Have: %v", ErrCloseTimeout, err)
+		}
+	})
+
+	t.Run("in-flight transactions finish", func(t *testing.T) {
+		db := newConcurrentSQLiteHandler(t, "sqlite3")
+
+		tx, err := db.Begin()
+		if err != nil {
+			t.Fatalf("error beginning transaction. error: %v", err.Error())
+		}
+
+		closed := make(chan error, 1)
+		go func() { closed <- db.Close() }()
+
+		// Allow Close to close the pool before the transaction continues
+		time.Sleep(50 * time.Millisecond)
+
+		if _, err := tx.Create(&testUser{Name: "Carl"}); err != nil {
+			t.Fatalf("error creating user. error: %v", err.Error())
+		}
+
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("error committing transaction. error: %v", err.Error())
+		}
+
+		if err := <-closed; err != nil {
+			t.Fatalf("error closing handler. error: %v", err.Error())
+		}
+	})
+
+	t.Run("multi tenant", func(t *testing.T) {
+		mtd := MultiTenantDialectHandler{Handlers: make(map[string]DialectHandler)}
+		mtd.Set("first", newSQLiteHandler(t))
+		mtd.Set("second", newSQLiteHandler(t))
+
+		if err := mtd.Close(); err != nil {
+			t.Fatalf("error closing handlers. error: %v", err.Error())
+		}
+
+		for name, db := range mtd.Handlers {
+			if err := db.Find(new(testUsers)); err == nil {
+				t.Fatalf("expected an error querying closed handler %s", name)
+			}
+		}
+	})
+}
@@ -1,9 +1,9 @@
 package dialects
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"sync"
 
 	_ "github.com/go-sql-driver/mysql"
 )
@@ -12,70 +12,271 @@ const DIALECT_TYPE_MYSQL = "mysql"
 
 type Mysql struct {
 	db     *sql.DB
-	mu     sync.Mutex
+	tx     *sql.Tx
+	txCtx  context.Context // Context the transaction or savepoint was started with
+	depth  int             // Nesting depth of the transaction, > 0 denotes a savepoint
+	stmts  *stmtCache
 	config DBConfig
 }
 
 var _ DialectHandler = (*Mysql)(nil)
 
-func (m *Mysql) Create(model any) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+func (m *Mysql) Create(model any) (Result, error) {
+	return m.CreateContext(context.Background(), model)
+}
+
+func (m *Mysql) CreateContext(ctx context.Context, model any) (Result, error) {
+	return Create(ctx, m.conn(), model, DIALECT_TYPE_MYSQL)
+}
+
+// CreateMany inserts all models of the slice using batched multi row INSERT statements
+func (m *Mysql) CreateMany(model any) (Result, error) {
+	return m.CreateManyContext(context.Background(), model)
+}
+
+func (m *Mysql) CreateManyContext(ctx context.Context, model any) (Result, error) {
+	return CreateMany(ctx, m.conn(), model, DIALECT_TYPE_MYSQL)
+}
+
+// Upsert inserts the model, updating the update columns of the existing row when the insert conflicts
+func (m *Mysql) Upsert(model any, conflictColumns []string, updateColumns []string) (Result, error) {
+	return m.UpsertContext(context.Background(), model, conflictColumns, updateColumns)
+}
+
+func (m *Mysql) UpsertContext(ctx context.Context, model any, conflictColumns []string, updateColumns []string) (Result, error) {
+	return Upsert(ctx, m.conn(), model, conflictColumns, updateColumns, DIALECT_TYPE_MYSQL)
+}
+
+func (m *Mysql) Update(model any, opts ...Option) (Result, error) {
+	return m.UpdateContext(context.Background(), model, opts...)
+}
+
+func (m *Mysql) UpdateContext(ctx context.Context, model any, opts ...Option) (Result, error) {
+	return Update(ctx, m.conn(), model, DIALECT_TYPE_MYSQL, opts...)
+}
+
+// UpdateAll writes every column of the model, including empty attributes
+func (m *Mysql) UpdateAll(model any) (Result, error) {
+	return m.UpdateAllContext(context.Background(), model)
+}
+
+func (m *Mysql) UpdateAllContext(ctx context.Context, model any) (Result, error) {
+	return UpdateAll(ctx, m.conn(), model, DIALECT_TYPE_MYSQL)
+}
+
+// UpdateMap sets the attributes of the model from the values keyed by column, then writes the columns
+func (m *Mysql) UpdateMap(model any, values map[string]any) (Result, error) {
+	return m.UpdateMapContext(context.Background(), model, values)
+}
 
-	return Create(m.db, model, DIALECT_TYPE_MYSQL)
+func (m *Mysql) UpdateMapContext(ctx context.Context, model any, values map[string]any) (Result, error) {
+	return UpdateMap(ctx, m.conn(), model, values, DIALECT_TYPE_MYSQL)
 }
 
-func (m *Mysql) Update(model any) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+// UpdateWhere sets the columns of the values on every row matching the statement, the amount of rows updated is returned
+func (m *Mysql) UpdateWhere(model any, values map[string]any, stmt string, args ...any) (int64, error) {
+	return m.UpdateWhereContext(context.Background(), model, values, stmt, args...)
+}
+
+func (m *Mysql) UpdateWhereContext(ctx context.Context, model any, values map[string]any, stmt string, args ...any) (int64, error) {
+	return UpdateWhere(ctx, m.conn(), model, values, stmt, DIALECT_TYPE_MYSQL, args...)
+}
 
-	return Update(m.db, model, DIALECT_TYPE_MYSQL)
+func (m *Mysql) Delete(model any) (Result, error) {
+	return m.DeleteContext(context.Background(), model)
 }
 
-func (m *Mysql) Delete(model any) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+func (m *Mysql) DeleteContext(ctx context.Context, model any) (Result, error) {
+	return Delete(ctx, m.conn(), model, DIALECT_TYPE_MYSQL)
+}
+
+// HardDelete deletes the rows matching the model, ignoring any soft delete attribute
+func (m *Mysql) HardDelete(model any) (Result, error) {
+	return m.HardDeleteContext(context.Background(), model)
+}
 
-	return Delete(m.db, model, DIALECT_TYPE_MYSQL)
+func (m *Mysql) HardDeleteContext(ctx context.Context, model any) (Result, error) {
+	return HardDelete(ctx, m.conn(), model, DIALECT_TYPE_MYSQL)
 }
 
-func (m *Mysql) BulkDelete(model any) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	return BulkDelete(m.db, model, DIALECT_TYPE_MYSQL)
+func (m *Mysql) BulkDelete(model any) (int64, error) {
+	return m.BulkDeleteContext(context.Background(), model)
+}
+
+func (m *Mysql) BulkDeleteContext(ctx context.Context, model any) (int64, error) {
+	return BulkDelete(ctx, m.conn(), model, DIALECT_TYPE_MYSQL)
+}
+
+// DeleteAll deletes every row of the table of the model, ConfirmAll must be passed
+func (m *Mysql) DeleteAll(model any, confirm Confirmation) (int64, error) {
+	return m.DeleteAllContext(context.Background(), model, confirm)
+}
+
+func (m *Mysql) DeleteAllContext(ctx context.Context, model any, confirm Confirmation) (int64, error) {
+	return DeleteAll(ctx, m.conn(), model, confirm, DIALECT_TYPE_MYSQL)
+}
+
+// DeleteWhere deletes every row matching the statement, the amount of rows deleted is returned
+func (m *Mysql) DeleteWhere(model any, stmt string, args ...any) (int64, error) {
+	return m.DeleteWhereContext(context.Background(), model, stmt, args...)
+}
+
+func (m *Mysql) DeleteWhereContext(ctx context.Context, model any, stmt string, args ...any) (int64, error) {
+	return DeleteWhere(ctx, m.conn(), model, stmt, DIALECT_TYPE_MYSQL, args...)
 }
 
 func (m *Mysql) Find(model any, args ...any) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	return m.FindContext(context.Background(), model, args...)
+}
 
-	return Find(m.db, model, DIALECT_TYPE_MYSQL, args...)
+func (m *Mysql) FindContext(ctx context.Context, model any, args ...any) error {
+	return Find(ctx, m.conn(), model, DIALECT_TYPE_MYSQL, args...)
 }
 
 func (m *Mysql) Where(model any, stmt string, limit int, args ...any) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	return m.WhereContext(context.Background(), model, stmt, limit, args...)
+}
 
-	return Where(m.db, model, stmt, limit, DIALECT_TYPE_MYSQL, args...)
+func (m *Mysql) WhereContext(ctx context.Context, model any, stmt string, limit int, args ...any) error {
+	return Where(ctx, m.conn(), model, stmt, limit, DIALECT_TYPE_MYSQL, args...)
 }
 
 func (m *Mysql) Raw(query string, args ...any) (*RawQuery, error) {
-	return Raw(m.db, query, args...)
+	return m.RawContext(context.Background(), query, args...)
+}
+
+func (m *Mysql) RawContext(ctx context.Context, query string, args ...any) (*RawQuery, error) {
+	return Raw(ctx, m.conn(), query, args...)
+}
+
+func (m *Mysql) Exec(query string, args ...any) error {
+	return m.ExecContext(context.Background(), query, args...)
+}
+
+func (m *Mysql) ExecContext(ctx context.Context, query string, args ...any) error {
+	return Exec(ctx, m.conn(), query, args...)
+}
+
+// Model starts a chainable query for the table of the given model
+func (m *Mysql) Model(model any) *ModelQuery {
+	return newModelQuery(m.conn(), model, DIALECT_TYPE_MYSQL)
+}
+
+// Association manages the join table rows of the named many to many relation of the model
+func (m *Mysql) Association(model any, name string) *Association {
+	return newAssociation(m.conn(), model, name, DIALECT_TYPE_MYSQL)
+}
+
+// AutoMigrate creates the tables for the given models, adding any missing columns to existing tables
+func (m *Mysql) AutoMigrate(models ...any) error {
+	return m.AutoMigrateContext(context.Background(), models...)
+}
+
+func (m *Mysql) AutoMigrateContext(ctx context.Context, models ...any) error {
+	return AutoMigrate(ctx, m.conn(), DIALECT_TYPE_MYSQL, models...)
+}
+
+func (m *Mysql) TableColumns(tableName string) (map[string]string, error) {
+	return m.TableColumnsContext(context.Background(), tableName)
+}
+
+func (m *Mysql) TableColumnsContext(ctx context.Context, tableName string) (map[string]string, error) {
+	return TableColumns(ctx, m.conn(), DIALECT_TYPE_MYSQL, tableName)
+}
+
+func (m *Mysql) Begin() (DialectHandler, error) {
+	return m.BeginTx(context.Background(), nil)
+}
+
+// BeginTx starts a transaction, the returned handler performs all actions within the transaction.
+// If the handler is already within a transaction, a savepoint is created instead and opts are ignored.
+func (m *Mysql) BeginTx(ctx context.Context, opts *sql.TxOptions) (DialectHandler, error) {
+	if m.tx != nil {
+		if err := savepoint(ctx, m.tx, m.depth+1); err != nil {
+			return nil, err
+		}
+
+		return &Mysql{db: m.db, tx: m.tx, txCtx: ctx, depth: m.depth + 1, stmts: m.stmts, config: m.config}, nil
+	}
+
+	tx, err := m.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mysql{db: m.db, tx: tx, txCtx: ctx, stmts: m.stmts, config: m.config}, nil
+}
+
+func (m *Mysql) Commit() error {
+	return commit(m.txCtx, m.tx, m.depth)
+}
+
+func (m *Mysql) Rollback() error {
+	return rollback(m.txCtx, m.tx, m.depth)
+}
+
+func (m *Mysql) Transaction(fn func(tx DialectHandler) error) error {
+	return m.TransactionContext(context.Background(), fn)
+}
+
+func (m *Mysql) TransactionContext(ctx context.Context, fn func(tx DialectHandler) error) error {
+	return transaction(ctx, m, fn)
+}
+
+// conn returns the transaction if one has been started, else the database connection pool.
+// Queries are executed using the cached prepared statements of the handler.
+func (m *Mysql) conn() Executor {
+	return &cachedConn{db: m.db, tx: m.tx, stmts: m.stmts}
 }
 
 // Alters the database that queries are for.
 func (m *Mysql) SetDB(connDB *sql.DB) {
+	// Statements of the previous cache were prepared on the previous connection pool
+	if m.stmts != nil {
+		m.stmts.close(false)
+	}
+
 	m.db = connDB
+	m.stmts = newStmtCache(m.config.StatementCacheSize)
+}
+
+// Close closes the database connection pool and the cached prepared statements, waiting up to DEFAULT_CLOSE_TIMEOUT for in-flight queries to finish.
+// Handlers within a transaction cannot be closed, Close the handler the transaction was started from.
+func (m *Mysql) Close() error {
+	return closeWithTimeout(m)
+}
+
+// CloseContext performs the same as Close, waiting for in-flight queries until the context is done
+func (m *Mysql) CloseContext(ctx context.Context) error {
+	return closeHandler(ctx, m, m.db, m.tx, m.stmts)
 }
 
+// SetConfig sets the config of the handler.
+// The prepared statement cache is rebuilt when the statementCacheSize differs, closing the statements cached so far.
 func (m *Mysql) SetConfig(config DBConfig) {
+	resize := m.db != nil && m.tx == nil && config.StatementCacheSize != m.config.StatementCacheSize
 	m.config = config
+
+	if resize {
+		if m.stmts != nil {
+			m.stmts.close(false)
+		}
+
+		m.stmts = newStmtCache(config.StatementCacheSize)
+	}
 }
 
 func (m *Mysql) GetConfig() DBConfig {
 	return m.config
 }
 
+// Dialect returns the dialect type used when building queries
+func (m *Mysql) Dialect() string {
+	return DIALECT_TYPE_MYSQL
+}
+
+// QueryString returns the DSN of the connection.
+// multiStatements allows a migration file holding several statements to be executed at once, the same as Postgres and SQLite.
 func (m *Mysql) QueryString() string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", m.config.User, m.config.Password, m.config.Host, m.config.Port, m.config.Database)
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?multiStatements=true", m.config.User, m.config.Password, m.config.Host, m.config.Port, m.config.Database)
 }
@@ -0,0 +1,16 @@
+package dialects
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMysqlQueryString(t *testing.T) {
+	m := &Mysql{}
+	m.SetConfig(DBConfig{User: "user", Password: "pass", Host: "localhost", Port: 3306, Database: "tinyorm"})
+
+	// Migration files holding several statements are executed at once
+	if dsn := m.QueryString(); !strings.HasSuffix(dsn, "?multiStatements=true") {
+		t.Fatalf("Wanted: multiStatements enabled - Have: %s", dsn)
+	}
+}
@@ -0,0 +1,79 @@
+package dialects
+
+// Option alters the behavior of a single action.
+// Options may be passed alongside the arguments of Find and Where, i.e. db.Find(users, dialects.Preload("Vehicles"))
+type Option func(*options)
+
+type options struct {
+	preload  []string
+	unscoped bool
+	columns  []string
+	all      bool
+	// The updated_at attribute was set by the caller and is written as is
+	keepUpdatedAt bool
+}
+
+// Preload loads the given associations after the parent rows are found.
+// Nested associations are separated by a dot, i.e. Preload("Vehicles.Parts")
+func Preload(associations ...string) Option {
+	return func(o *options) {
+		o.preload = append(o.preload, associations...)
+	}
+}
+
+// Unscoped includes soft deleted rows within the results
+func Unscoped() Option {
+	return func(o *options) {
+		o.unscoped = true
+	}
+}
+
+// Columns limits Update to the given columns. The columns are written even when the attributes are empty, i.e. 0, false or "".
+// i.e. db.Update(user, dialects.Columns("age", "active"))
+func Columns(columns ...string) Option {
+	return func(o *options) {
+		o.columns = append(o.columns, columns...)
+	}
+}
+
+// Writes every column of the model on Update, used by UpdateAll
+func allColumns() Option {
+	return func(o *options) {
+		o.all = true
+	}
+}
+
+// Keeps the updated_at attribute of the model on Update, used by UpdateMap when the values hold updated_at
+func keepUpdatedAt() Option {
+	return func(o *options) {
+		o.keepUpdatedAt = true
+	}
+}
+
+func applyOptions(opts []Option) options {
+	var o options
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// Separates any options from the query arguments
+func splitOptions(args []any) ([]any, options) {
+	var o options
+	var queryArgs []any
+
+	for _, a := range args {
+		if opt, ok := a.(Option); ok {
+			opt(&o)
+
+			continue
+		}
+
+		queryArgs = append(queryArgs, a)
+	}
+
+	return queryArgs, o
+}
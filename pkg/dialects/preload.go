@@ -0,0 +1,286 @@
+package dialects
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/logger"
+	"github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
+)
+
+// preload loads each of the associations for the model, model is a pointer to a struct or to a slice of structs.
+// A batched IN query is performed per association, the results are then stitched into the parent models.
+// Keys exceeding the parameter limit of the database are split across multiple queries.
+func preload(ctx context.Context, db Executor, dialectType string, model any, associations []string) error {
+	if len(associations) == 0 {
+		return nil
+	}
+
+	parents := parentValues(model)
+	for _, association := range associations {
+		if err := preloadAssociation(ctx, db, dialectType, parents, association); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func preloadAssociation(ctx context.Context, db Executor, dialectType string, parents []reflect.Value, association string) error {
+	if len(parents) == 0 {
+		return nil
+	}
+
+	name, nested, _ := strings.Cut(association, ".")
+	parentType := parents[0].Type()
+
+	relation, err := sqlbuilder.RelationOf(parentType, name)
+	if err != nil {
+		return err
+	}
+
+	if relation.Kind == sqlbuilder.MANY_TO_MANY {
+		return preloadManyToMany(ctx, db, dialectType, parents, relation, nested)
+	}
+
+	// The column of the parent holding the key, and the column of the associated model matched against the key
+	parentColumn, childColumn := "id", relation.ForeignKey
+	if relation.Kind == sqlbuilder.BELONGS_TO {
+		parentColumn, childColumn = relation.ForeignKey, "id"
+	}
+
+	parentField, found := sqlbuilder.FieldByColumn(parentType, parentColumn)
+	if !found {
+		return fmt.Errorf("no attribute was found on model %s for column %s", parentType.Name(), parentColumn)
+	}
+
+	childField, found := sqlbuilder.FieldByColumn(relation.Model, childColumn)
+	if !found {
+		return fmt.Errorf("no attribute was found on model %s for column %s", relation.Model.Name(), childColumn)
+	}
+
+	keys := columnValues(parents, parentField)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	children := reflect.New(reflect.SliceOf(relation.Model))
+	for _, batch := range keyBatches(keys, maxParameters(dialectType)) {
+		found := reflect.New(reflect.SliceOf(relation.Model))
+		mq := newModelQuery(db, found.Interface(), dialectType).Context(ctx).Where(fmt.Sprintf("%s IN (%s)", childColumn, sqlbuilder.Placeholders(len(batch))), batch...)
+		if nested != "" {
+			mq.Preload(nested)
+		}
+
+		if err := mq.All(); err != nil {
+			return fmt.Errorf("error preloading %s for model %s. Error: %w", name, parentType.Name(), err)
+		}
+
+		children.Elem().Set(reflect.AppendSlice(children.Elem(), found.Elem()))
+	}
+
+	grouped := make(map[string][]reflect.Value)
+	for i := 0; i < children.Elem().Len(); i++ {
+		child := children.Elem().Index(i)
+		key := relationKey(child.FieldByIndex(childField.Index))
+		grouped[key] = append(grouped[key], child)
+	}
+
+	for _, parent := range parents {
+		matches := grouped[relationKey(parent.FieldByIndex(parentField.Index))]
+		setRelation(parent.FieldByIndex(relation.Field.Index), matches)
+	}
+
+	return nil
+}
+
+// Many to many relations are loaded using a query joining the associated table with the join table, per batch of keys.
+// The join table key is selected alongside the columns of the associated model to group the results per parent.
+func preloadManyToMany(ctx context.Context, db Executor, dialectType string, parents []reflect.Value, relation sqlbuilder.Relation, nested string) error {
+	var parentKeys []string
+
+	parentType := parents[0].Type()
+	parentField, found := sqlbuilder.FieldByColumn(parentType, "id")
+	if !found {
+		return fmt.Errorf("no attribute was found on model %s for column id", parentType.Name())
+	}
+
+	keys := columnValues(parents, parentField)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	children := reflect.New(reflect.SliceOf(relation.Model))
+	for _, batch := range keyBatches(keys, maxParameters(dialectType)) {
+		batchKeys, err := queryManyToMany(ctx, db, dialectType, relation, batch, children)
+		if err != nil {
+			return fmt.Errorf("error preloading %s for model %s. Error: %w", relation.Field.Name, parentType.Name(), err)
+		}
+
+		parentKeys = append(parentKeys, batchKeys...)
+	}
+
+	if nested != "" {
+		if err := preload(ctx, db, dialectType, children.Interface(), []string{nested}); err != nil {
+			return err
+		}
+	}
+
+	grouped := make(map[string][]reflect.Value)
+	for i, key := range parentKeys {
+		grouped[key] = append(grouped[key], children.Elem().Index(i))
+	}
+
+	for _, parent := range parents {
+		matches := grouped[relationKey(parent.FieldByIndex(parentField.Index))]
+		setRelation(parent.FieldByIndex(relation.Field.Index), matches)
+	}
+
+	return nil
+}
+
+// Appends the associated models of the keys onto children, returning the join table key of each associated model
+func queryManyToMany(ctx context.Context, db Executor, dialectType string, relation sqlbuilder.Relation, keys []any, children reflect.Value) ([]string, error) {
+	var query sqlbuilder.SelectQuery
+	var parentKeys []string
+
+	tableName := sqlbuilder.TableName(relation.Model)
+	joinKey := relation.JoinTable + "." + relation.JoinForeignKey
+	query.TableName = fmt.Sprintf("%s INNER JOIN %s ON %s.%s = %s.id", tableName, relation.JoinTable, relation.JoinTable, relation.JoinReferences, tableName)
+	query.Columns = joinKey + ", " + sqlbuilder.QualifiedCoalesceQueryBuilder(relation.Model, tableName)
+	query.Where(fmt.Sprintf("%s IN (%s)", joinKey, sqlbuilder.Placeholders(len(keys))), keys...)
+	if column, found := sqlbuilder.SoftDeleteColumn(relation.Model); found {
+		query.Where(tableName + "." + column + " IS NULL")
+	}
+
+	q, args := query.Build(dialectType)
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.LogError("error closing database rows.", err)
+		}
+	}()
+
+	for rows.Next() {
+		var key any
+		child := reflect.New(relation.Model)
+
+		if err := rows.Scan(append([]any{&key}, sqlbuilder.PointerAttributes(child)...)...); err != nil {
+			return nil, err
+		}
+
+		parentKeys = append(parentKeys, relationKey(reflect.ValueOf(key)))
+		children.Elem().Set(reflect.Append(children.Elem(), child.Elem()))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return parentKeys, rows.Close()
+}
+
+// Splits the keys into batches of at most size keys, keeping each IN query within the parameter limit of the database
+func keyBatches(keys []any, size int) [][]any {
+	var batches [][]any
+
+	for start := 0; start < len(keys); start += size {
+		end := start + size
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		batches = append(batches, keys[start:end])
+	}
+
+	return batches
+}
+
+// Returns the addressable struct values of the model
+func parentValues(model any) []reflect.Value {
+	value := reflect.Indirect(reflect.ValueOf(model))
+	if value.Kind() != reflect.Slice {
+		return []reflect.Value{value}
+	}
+
+	parents := make([]reflect.Value, value.Len())
+	for i := range parents {
+		parents[i] = value.Index(i)
+	}
+
+	return parents
+}
+
+// Returns the unique, non zero values of the field across all of the models
+func columnValues(models []reflect.Value, field reflect.StructField) []any {
+	var values []any
+	seen := make(map[string]struct{})
+
+	for _, m := range models {
+		v := m.FieldByIndex(field.Index)
+		if v.IsZero() {
+			continue
+		}
+
+		key := relationKey(v)
+		if _, found := seen[key]; found {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		values = append(values, v.Interface())
+	}
+
+	return values
+}
+
+// Normalizes the key values so keys of differing go types can be matched, i.e. uuid.UUID and string
+func relationKey(v reflect.Value) string {
+	value := v.Interface()
+
+	if valuer, ok := value.(driver.Valuer); ok {
+		if dv, err := valuer.Value(); err == nil {
+			value = dv
+		}
+	}
+
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// Sets the found associated models on the relation attribute of the parent
+func setRelation(field reflect.Value, matches []reflect.Value) {
+	switch field.Kind() {
+	case reflect.Slice:
+		s := reflect.MakeSlice(field.Type(), 0, len(matches))
+		for _, m := range matches {
+			if field.Type().Elem().Kind() == reflect.Ptr {
+				s = reflect.Append(s, m.Addr())
+
+				continue
+			}
+
+			s = reflect.Append(s, m)
+		}
+
+		field.Set(s)
+	case reflect.Ptr:
+		if len(matches) > 0 {
+			field.Set(matches[0].Addr())
+		}
+	default:
+		if len(matches) > 0 {
+			field.Set(matches[0])
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package dialects
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type owner struct {
+	ID      uuid.UUID
+	Name    string
+	Cars    []car    `tinyorm:"has_many"`
+	License *license `tinyorm:"has_one,foreign_key=owner_id"`
+}
+
+type car struct {
+	ID      uuid.UUID
+	OwnerID uuid.UUID
+	Color   string
+	Owner   *owner `tinyorm:"belongs_to"`
+	Parts   []part `tinyorm:"has_many"`
+}
+
+type part struct {
+	ID    uuid.UUID
+	CarID uuid.UUID
+	Name  string
+}
+
+type license struct {
+	ID      uuid.UUID
+	OwnerID uuid.UUID
+	Number  string
+}
+
+func TestPreload(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&owner{}, &car{}, &part{}, &license{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	carl, bob := &owner{ID: uuid.New(), Name: "Carl"}, &owner{ID: uuid.New(), Name: "Bob"}
+	red, blue := &car{ID: uuid.New(), OwnerID: carl.ID, Color: "Red"}, &car{ID: uuid.New(), OwnerID: carl.ID, Color: "Blue"}
+	models := []any{
+		carl, bob, red, blue,
+		&car{ID: uuid.New(), OwnerID: bob.ID, Color: "Green"},
+		&part{CarID: red.ID, Name: "Wheel"},
+		&part{CarID: red.ID, Name: "Door"},
+		&license{OwnerID: bob.ID, Number: "1234"},
+	}
+
+	for _, m := range models {
+		if _, err := db.Create(m); err != nil {
+			t.Fatalf("error creating model. error: %v", err.Error())
+		}
+	}
+
+	owners := new([]owner)
+	if err := db.Model(owners).Order("name asc").Preload("Cars.Parts", "License").All(); err != nil {
+		t.Fatalf("error preloading owners. error: %v", err.Error())
+	}
+
+	if len(*owners) != 2 {
+		t.Fatalf("Wanted: 2 owners - Have: %d", len(*owners))
+	}
+
+	b, c := (*owners)[0], (*owners)[1]
+	if len(b.Cars) != 1 || len(c.Cars) != 2 {
+		t.Fatalf("Wanted: 1 and 2 cars - Have: %d and %d", len(b.Cars), len(c.Cars))
+	}
+
+	if b.License == nil || b.License.Number != "1234" || c.License != nil {
+		t.Fatalf("unexpected licenses. Bob: %v Carl: %v", b.License, c.License)
+	}
+
+	var parts int
+	for _, cr := range c.Cars {
+		parts += len(cr.Parts)
+	}
+
+	if parts != 2 {
+		t.Fatalf("Wanted: 2 parts - Have: %d", parts)
+	}
+
+	found := new(car)
+	if err := db.Find(found, red.ID, Preload("Owner")); err != nil {
+		t.Fatalf("error finding car. error: %v", err.Error())
+	}
+
+	if found.Owner == nil || found.Owner.Name != "Carl" {
+		t.Fatalf("Wanted: owner Carl - Have: %v", found.Owner)
+	}
+
+	cars := new([]car)
+	if err := db.Where(cars, "color != ?", 0, "Green", Preload("Owner")); err != nil {
+		t.Fatalf("error finding cars. error: %v", err.Error())
+	}
+
+	for _, cr := range *cars {
+		if cr.Owner == nil || cr.Owner.ID != carl.ID {
+			t.Fatalf("Wanted: owner Carl - Have: %v", cr.Owner)
+		}
+	}
+}
+
+type studentCourse struct {
+	StudentID uuid.UUID
+	CourseID  uuid.UUID
+}
+
+func TestPreloadBatches(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&owner{}, &car{}, &student{}, &course{}, &studentCourse{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	// More parents than parameters allowed within a single query
+	rows := sqliteMaxParameters() + 10
+
+	owners, cars := make([]owner, rows), make([]car, rows)
+	students, joins := make([]student, rows), make([]studentCourse, rows)
+	math := &course{ID: uuid.New(), Title: "Math"}
+	for i := range owners {
+		owners[i] = owner{ID: uuid.New(), Name: "owner"}
+		cars[i] = car{ID: uuid.New(), OwnerID: owners[i].ID, Color: "red"}
+		students[i] = student{ID: uuid.New(), Name: "student"}
+		joins[i] = studentCourse{StudentID: students[i].ID, CourseID: math.ID}
+	}
+
+	if _, err := db.Create(math); err != nil {
+		t.Fatalf("error creating course. error: %v", err.Error())
+	}
+
+	for _, models := range []any{&owners, &cars, &students, &joins} {
+		if _, err := db.CreateMany(models); err != nil {
+			t.Fatalf("error creating models. error: %v", err.Error())
+		}
+	}
+
+	tests := map[string]struct {
+		model  any
+		loaded func(model any) int
+	}{
+		"has many": {
+			model: new([]owner),
+			loaded: func(model any) (n int) {
+				for _, o := range *model.(*[]owner) {
+					n += len(o.Cars)
+				}
+				return n
+			},
+		},
+		"belongs to": {
+			model: new([]car),
+			loaded: func(model any) (n int) {
+				for _, c := range *model.(*[]car) {
+					if c.Owner != nil {
+						n++
+					}
+				}
+				return n
+			},
+		},
+		"many to many": {
+			model: new([]student),
+			loaded: func(model any) (n int) {
+				for _, s := range *model.(*[]student) {
+					n += len(s.Courses)
+				}
+				return n
+			},
+		},
+	}
+
+	associations := map[string]string{"has many": "Cars", "belongs to": "Owner", "many to many": "Courses"}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := db.Find(test.model, Preload(associations[name])); err != nil {
+				t.Fatalf("error preloading %s. error: %v", associations[name], err.Error())
+			}
+
+			if loaded := test.loaded(test.model); loaded != rows {
+				t.Fatalf("Wanted: %d - Have: %d", rows, loaded)
+			}
+		})
+	}
+}
@@ -1,16 +1,19 @@
 package dialects
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"sync"
 
 	_ "github.com/lib/pq"
 )
 
 type Postgres struct {
 	db     *sql.DB
-	mu     sync.Mutex
+	tx     *sql.Tx
+	txCtx  context.Context // Context the transaction or savepoint was started with
+	depth  int             // Nesting depth of the transaction, > 0 denotes a savepoint
+	stmts  *stmtCache
 	config DBConfig
 }
 
@@ -18,62 +21,261 @@ const DIALECT_TYPE_PSQL = "psql"
 
 var _ DialectHandler = (*Postgres)(nil)
 
-func (pd *Postgres) Create(model any) error {
-	pd.mu.Lock()
-	defer pd.mu.Unlock()
-	return Create(pd.db, model, DIALECT_TYPE_PSQL)
+func (pd *Postgres) Create(model any) (Result, error) {
+	return pd.CreateContext(context.Background(), model)
 }
 
-func (pd *Postgres) Update(model any) error {
-	pd.mu.Lock()
-	defer pd.mu.Unlock()
+func (pd *Postgres) CreateContext(ctx context.Context, model any) (Result, error) {
+	return Create(ctx, pd.conn(), model, DIALECT_TYPE_PSQL)
+}
+
+// CreateMany inserts all models of the slice using batched multi row INSERT statements
+func (pd *Postgres) CreateMany(model any) (Result, error) {
+	return pd.CreateManyContext(context.Background(), model)
+}
+
+func (pd *Postgres) CreateManyContext(ctx context.Context, model any) (Result, error) {
+	return CreateMany(ctx, pd.conn(), model, DIALECT_TYPE_PSQL)
+}
+
+// Upsert inserts the model, updating the update columns of the existing row when the insert conflicts
+func (pd *Postgres) Upsert(model any, conflictColumns []string, updateColumns []string) (Result, error) {
+	return pd.UpsertContext(context.Background(), model, conflictColumns, updateColumns)
+}
+
+func (pd *Postgres) UpsertContext(ctx context.Context, model any, conflictColumns []string, updateColumns []string) (Result, error) {
+	return Upsert(ctx, pd.conn(), model, conflictColumns, updateColumns, DIALECT_TYPE_PSQL)
+}
+
+func (pd *Postgres) Update(model any, opts ...Option) (Result, error) {
+	return pd.UpdateContext(context.Background(), model, opts...)
+}
+
+func (pd *Postgres) UpdateContext(ctx context.Context, model any, opts ...Option) (Result, error) {
+	return Update(ctx, pd.conn(), model, DIALECT_TYPE_PSQL, opts...)
+}
+
+// UpdateAll writes every column of the model, including empty attributes
+func (pd *Postgres) UpdateAll(model any) (Result, error) {
+	return pd.UpdateAllContext(context.Background(), model)
+}
+
+func (pd *Postgres) UpdateAllContext(ctx context.Context, model any) (Result, error) {
+	return UpdateAll(ctx, pd.conn(), model, DIALECT_TYPE_PSQL)
+}
+
+// UpdateMap sets the attributes of the model from the values keyed by column, then writes the columns
+func (pd *Postgres) UpdateMap(model any, values map[string]any) (Result, error) {
+	return pd.UpdateMapContext(context.Background(), model, values)
+}
+
+func (pd *Postgres) UpdateMapContext(ctx context.Context, model any, values map[string]any) (Result, error) {
+	return UpdateMap(ctx, pd.conn(), model, values, DIALECT_TYPE_PSQL)
+}
 
-	return Update(pd.db, model, DIALECT_TYPE_PSQL)
+// UpdateWhere sets the columns of the values on every row matching the statement, the amount of rows updated is returned
+func (pd *Postgres) UpdateWhere(model any, values map[string]any, stmt string, args ...any) (int64, error) {
+	return pd.UpdateWhereContext(context.Background(), model, values, stmt, args...)
 }
 
-func (pd *Postgres) Delete(model any) error {
-	pd.mu.Lock()
-	defer pd.mu.Unlock()
+func (pd *Postgres) UpdateWhereContext(ctx context.Context, model any, values map[string]any, stmt string, args ...any) (int64, error) {
+	return UpdateWhere(ctx, pd.conn(), model, values, stmt, DIALECT_TYPE_PSQL, args...)
+}
+
+func (pd *Postgres) Delete(model any) (Result, error) {
+	return pd.DeleteContext(context.Background(), model)
+}
+
+func (pd *Postgres) DeleteContext(ctx context.Context, model any) (Result, error) {
+	return Delete(ctx, pd.conn(), model, DIALECT_TYPE_PSQL)
+}
+
+// HardDelete deletes the rows matching the model, ignoring any soft delete attribute
+func (pd *Postgres) HardDelete(model any) (Result, error) {
+	return pd.HardDeleteContext(context.Background(), model)
+}
+
+func (pd *Postgres) HardDeleteContext(ctx context.Context, model any) (Result, error) {
+	return HardDelete(ctx, pd.conn(), model, DIALECT_TYPE_PSQL)
+}
+
+func (pd *Postgres) BulkDelete(model any) (int64, error) {
+	return pd.BulkDeleteContext(context.Background(), model)
+}
 
-	return Delete(pd.db, model, DIALECT_TYPE_PSQL)
+func (pd *Postgres) BulkDeleteContext(ctx context.Context, model any) (int64, error) {
+	return BulkDelete(ctx, pd.conn(), model, DIALECT_TYPE_PSQL)
 }
 
-func (pd *Postgres) BulkDelete(model any) error {
-	pd.mu.Lock()
-	defer pd.mu.Unlock()
-	return BulkDelete(pd.db, model, DIALECT_TYPE_PSQL)
+// DeleteAll deletes every row of the table of the model, ConfirmAll must be passed
+func (pd *Postgres) DeleteAll(model any, confirm Confirmation) (int64, error) {
+	return pd.DeleteAllContext(context.Background(), model, confirm)
+}
+
+func (pd *Postgres) DeleteAllContext(ctx context.Context, model any, confirm Confirmation) (int64, error) {
+	return DeleteAll(ctx, pd.conn(), model, confirm, DIALECT_TYPE_PSQL)
+}
+
+// DeleteWhere deletes every row matching the statement, the amount of rows deleted is returned
+func (pd *Postgres) DeleteWhere(model any, stmt string, args ...any) (int64, error) {
+	return pd.DeleteWhereContext(context.Background(), model, stmt, args...)
+}
+
+func (pd *Postgres) DeleteWhereContext(ctx context.Context, model any, stmt string, args ...any) (int64, error) {
+	return DeleteWhere(ctx, pd.conn(), model, stmt, DIALECT_TYPE_PSQL, args...)
 }
 
 func (pd *Postgres) Find(model any, args ...any) error {
-	pd.mu.Lock()
-	defer pd.mu.Unlock()
+	return pd.FindContext(context.Background(), model, args...)
+}
 
-	return Find(pd.db, model, DIALECT_TYPE_PSQL, args...)
+func (pd *Postgres) FindContext(ctx context.Context, model any, args ...any) error {
+	return Find(ctx, pd.conn(), model, DIALECT_TYPE_PSQL, args...)
 }
 
 func (pd *Postgres) Where(model any, stmt string, limit int, args ...any) error {
-	pd.mu.Lock()
-	defer pd.mu.Unlock()
+	return pd.WhereContext(context.Background(), model, stmt, limit, args...)
+}
 
-	return Where(pd.db, model, stmt, limit, DIALECT_TYPE_PSQL, args...)
+func (pd *Postgres) WhereContext(ctx context.Context, model any, stmt string, limit int, args ...any) error {
+	return Where(ctx, pd.conn(), model, stmt, limit, DIALECT_TYPE_PSQL, args...)
 }
 
 func (pd *Postgres) Raw(query string, args ...any) (*RawQuery, error) {
-	return Raw(pd.db, query, args...)
+	return pd.RawContext(context.Background(), query, args...)
+}
+
+func (pd *Postgres) RawContext(ctx context.Context, query string, args ...any) (*RawQuery, error) {
+	return Raw(ctx, pd.conn(), query, args...)
 }
 
+func (pd *Postgres) Exec(query string, args ...any) error {
+	return pd.ExecContext(context.Background(), query, args...)
+}
+
+func (pd *Postgres) ExecContext(ctx context.Context, query string, args ...any) error {
+	return Exec(ctx, pd.conn(), query, args...)
+}
+
+// Model starts a chainable query for the table of the given model
+func (pd *Postgres) Model(model any) *ModelQuery {
+	return newModelQuery(pd.conn(), model, DIALECT_TYPE_PSQL)
+}
+
+// Association manages the join table rows of the named many to many relation of the model
+func (pd *Postgres) Association(model any, name string) *Association {
+	return newAssociation(pd.conn(), model, name, DIALECT_TYPE_PSQL)
+}
+
+// AutoMigrate creates the tables for the given models, adding any missing columns to existing tables
+func (pd *Postgres) AutoMigrate(models ...any) error {
+	return pd.AutoMigrateContext(context.Background(), models...)
+}
+
+func (pd *Postgres) AutoMigrateContext(ctx context.Context, models ...any) error {
+	return AutoMigrate(ctx, pd.conn(), DIALECT_TYPE_PSQL, models...)
+}
+
+func (pd *Postgres) TableColumns(tableName string) (map[string]string, error) {
+	return pd.TableColumnsContext(context.Background(), tableName)
+}
+
+func (pd *Postgres) TableColumnsContext(ctx context.Context, tableName string) (map[string]string, error) {
+	return TableColumns(ctx, pd.conn(), DIALECT_TYPE_PSQL, tableName)
+}
+
+func (pd *Postgres) Begin() (DialectHandler, error) {
+	return pd.BeginTx(context.Background(), nil)
+}
+
+// BeginTx starts a transaction, the returned handler performs all actions within the transaction.
+// If the handler is already within a transaction, a savepoint is created instead and opts are ignored.
+func (pd *Postgres) BeginTx(ctx context.Context, opts *sql.TxOptions) (DialectHandler, error) {
+	if pd.tx != nil {
+		if err := savepoint(ctx, pd.tx, pd.depth+1); err != nil {
+			return nil, err
+		}
+
+		return &Postgres{db: pd.db, tx: pd.tx, txCtx: ctx, depth: pd.depth + 1, stmts: pd.stmts, config: pd.config}, nil
+	}
+
+	tx, err := pd.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Postgres{db: pd.db, tx: tx, txCtx: ctx, stmts: pd.stmts, config: pd.config}, nil
+}
+
+func (pd *Postgres) Commit() error {
+	return commit(pd.txCtx, pd.tx, pd.depth)
+}
+
+func (pd *Postgres) Rollback() error {
+	return rollback(pd.txCtx, pd.tx, pd.depth)
+}
+
+func (pd *Postgres) Transaction(fn func(tx DialectHandler) error) error {
+	return pd.TransactionContext(context.Background(), fn)
+}
+
+func (pd *Postgres) TransactionContext(ctx context.Context, fn func(tx DialectHandler) error) error {
+	return transaction(ctx, pd, fn)
+}
+
+// conn returns the transaction if one has been started, else the database connection pool.
+// Queries are executed using the cached prepared statements of the handler.
+func (pd *Postgres) conn() Executor {
+	return &cachedConn{db: pd.db, tx: pd.tx, stmts: pd.stmts}
+}
+
+// SetDB sets the database connection pool, creating the prepared statement cache sized by the statementCacheSize of the config.
+// The statements cached for a previously set connection pool are closed.
 func (pd *Postgres) SetDB(connDB *sql.DB) {
+	// Statements of the previous cache were prepared on the previous connection pool
+	if pd.stmts != nil {
+		pd.stmts.close(false)
+	}
+
 	pd.db = connDB
+	pd.stmts = newStmtCache(pd.config.StatementCacheSize)
+}
+
+// Close closes the database connection pool and the cached prepared statements, waiting up to DEFAULT_CLOSE_TIMEOUT for in-flight queries to finish.
+// Handlers within a transaction cannot be closed, Close the handler the transaction was started from.
+func (pd *Postgres) Close() error {
+	return closeWithTimeout(pd)
+}
+
+// CloseContext performs the same as Close, waiting for in-flight queries until the context is done
+func (pd *Postgres) CloseContext(ctx context.Context) error {
+	return closeHandler(ctx, pd, pd.db, pd.tx, pd.stmts)
 }
 
+// SetConfig sets the config of the handler.
+// The prepared statement cache is rebuilt when the statementCacheSize differs, closing the statements cached so far.
 func (pd *Postgres) SetConfig(config DBConfig) {
+	resize := pd.db != nil && pd.tx == nil && config.StatementCacheSize != pd.config.StatementCacheSize
 	pd.config = config
+
+	if resize {
+		if pd.stmts != nil {
+			pd.stmts.close(false)
+		}
+
+		pd.stmts = newStmtCache(config.StatementCacheSize)
+	}
 }
 
 func (pd *Postgres) GetConfig() DBConfig {
 	return pd.config
 }
 
+// Dialect returns the dialect type used when building queries
+func (pd *Postgres) Dialect() string {
+	return DIALECT_TYPE_PSQL
+}
+
 func (pd *Postgres) QueryString() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password =%s dbname=%s sslmode=%s", pd.config.Host, pd.config.Port, pd.config.User, pd.config.Password, pd.config.Database, "disable")
 }
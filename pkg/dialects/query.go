@@ -0,0 +1,277 @@
+package dialects
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/logger"
+	"github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
+)
+
+// ModelQuery is a chainable query for the table of the given model.
+// The query is only executed when calling All, First or Count.
+// i.e. db.Model(&users).Where("age > ?", 18).Order("created_at desc").Limit(10).All()
+type ModelQuery struct {
+	ctx         context.Context
+	db          Executor
+	model       any
+	dialectType string
+	columns     []string
+	query       sqlbuilder.SelectQuery
+	options     options
+	scoped      bool
+	err         error
+}
+
+func newModelQuery(db Executor, model any, dialectType string) *ModelQuery {
+	mq := &ModelQuery{
+		ctx:         context.Background(),
+		db:          db,
+		model:       model,
+		dialectType: dialectType,
+	}
+
+	if sqlbuilder.IsPointer(model) {
+		mq.err = fmt.Errorf("pointer not passed. Please pass a pointer to the model")
+
+		return mq
+	}
+
+	mq.query.TableName = sqlbuilder.TableName(reflect.TypeOf(model).Elem())
+
+	return mq
+}
+
+// Context sets the context used when executing the query
+func (mq *ModelQuery) Context(ctx context.Context) *ModelQuery {
+	mq.ctx = ctx
+
+	return mq
+}
+
+// Select limits the columns selected from the table. Only the matching model attributes are filled.
+func (mq *ModelQuery) Select(columns ...string) *ModelQuery {
+	mq.columns = append(mq.columns, columns...)
+
+	return mq
+}
+
+// Where appends a condition to the query. Multiple calls to Where are joined with AND.
+// Use ? as the placeholder for arguments regardless of the database dialect.
+func (mq *ModelQuery) Where(stmt string, args ...any) *ModelQuery {
+	if stmt == "" {
+		mq.err = errors.New("you cannot pass an empty statement")
+
+		return mq
+	}
+
+	mq.query.Where(stmt, args...)
+
+	return mq
+}
+
+// Order appends an ordering to the query, i.e. "created_at desc"
+func (mq *ModelQuery) Order(order string) *ModelQuery {
+	mq.query.Order(order)
+
+	return mq
+}
+
+// Limit sets the maximum amount of rows returned, a limit of <= 0 will return all rows
+func (mq *ModelQuery) Limit(limit int) *ModelQuery {
+	mq.query.Limit(limit)
+
+	return mq
+}
+
+// Offset sets the amount of rows to skip
+func (mq *ModelQuery) Offset(offset int) *ModelQuery {
+	mq.query.Offset(offset)
+
+	return mq
+}
+
+// Preload loads the given associations after the rows are found
+func (mq *ModelQuery) Preload(associations ...string) *ModelQuery {
+	Preload(associations...)(&mq.options)
+
+	return mq
+}
+
+// Unscoped includes soft deleted rows within the results
+func (mq *ModelQuery) Unscoped() *ModelQuery {
+	Unscoped()(&mq.options)
+
+	return mq
+}
+
+// Applies the options passed alongside the arguments of an action
+func (mq *ModelQuery) apply(o options) *ModelQuery {
+	mq.options = o
+
+	return mq
+}
+
+// All executes the query. If the model is a slice, the slice is filled with all found rows.
+// If the model is not a slice, the first row found is scanned into the model.
+func (mq *ModelQuery) All() error {
+	if mq.err != nil {
+		return mq.err
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(mq.model))
+	if value.Kind() != reflect.Slice {
+		return mq.First()
+	}
+
+	if err := mq.selectColumns(value.Type().Elem()); err != nil {
+		return err
+	}
+	mq.scope(value.Type().Elem())
+
+	query, args := mq.query.Build(mq.dialectType)
+	if err := mq.scanRows(query, args, value); err != nil {
+		return err
+	}
+
+	if err := preload(mq.ctx, mq.db, mq.dialectType, mq.model, mq.options.preload); err != nil {
+		return err
+	}
+
+	return afterFind(mq.ctx, mq.model)
+}
+
+// First executes the query returning only the first row found.
+// sql.ErrNoRows is returned if no rows matched the query
+func (mq *ModelQuery) First() error {
+	if mq.err != nil {
+		return mq.err
+	}
+
+	mq.query.Limit(1)
+
+	value := reflect.Indirect(reflect.ValueOf(mq.model))
+	if value.Kind() == reflect.Slice {
+		return mq.All()
+	}
+
+	if err := mq.selectColumns(value.Type()); err != nil {
+		return err
+	}
+	mq.scope(value.Type())
+
+	query, args := mq.query.Build(mq.dialectType)
+	pointers, err := mq.pointers(value)
+	if err != nil {
+		return err
+	}
+
+	if err := mq.db.QueryRowContext(mq.ctx, query, args...).Scan(pointers...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		return fmt.Errorf("error scanning rows for table: %s. Error: %w", mq.query.TableName, err)
+	}
+
+	if err := preload(mq.ctx, mq.db, mq.dialectType, mq.model, mq.options.preload); err != nil {
+		return err
+	}
+
+	return afterFind(mq.ctx, mq.model)
+}
+
+// Count returns the amount of rows matching the conditions of the query, ignoring limit and offset
+func (mq *ModelQuery) Count() (int64, error) {
+	var count int64
+
+	if mq.err != nil {
+		return count, mq.err
+	}
+
+	mq.scope(sqlbuilder.ModelType(mq.model))
+	query, args := mq.query.BuildCount(mq.dialectType)
+	err := mq.db.QueryRowContext(mq.ctx, query, args...).Scan(&count)
+
+	return count, err
+}
+
+// Excludes soft deleted rows from the query unless the query is unscoped
+func (mq *ModelQuery) scope(model reflect.Type) {
+	if mq.scoped || mq.options.unscoped {
+		return
+	}
+	mq.scoped = true
+
+	if column, found := sqlbuilder.SoftDeleteColumn(model); found {
+		mq.query.Where(column + " IS NULL")
+	}
+}
+
+// Sets the columns of the select statement, wrapping each in COALESCE to protect from null values
+func (mq *ModelQuery) selectColumns(model reflect.Type) error {
+	if len(mq.columns) == 0 {
+		mq.query.Columns = sqlbuilder.CoalesceQueryBuilder(model)
+
+		return nil
+	}
+
+	columns, err := sqlbuilder.CoalesceColumnBuilder(model, mq.columns)
+	if err != nil {
+		return err
+	}
+	mq.query.Columns = columns
+
+	return nil
+}
+
+func (mq *ModelQuery) pointers(value reflect.Value) ([]any, error) {
+	if len(mq.columns) == 0 {
+		return sqlbuilder.PointerAttributes(value), nil
+	}
+
+	return sqlbuilder.ColumnPointers(value, mq.columns)
+}
+
+// Scans all rows into a new slice which then replaces the value of the model
+func (mq *ModelQuery) scanRows(query string, args []any, value reflect.Value) error {
+	rows, err := mq.db.QueryContext(mq.ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.LogError("error closing database rows.", err)
+		}
+	}()
+
+	newS := reflect.MakeSlice(reflect.SliceOf(value.Type().Elem()), 0, 0)
+	for rows.Next() {
+		var newVal reflect.Value
+		newS, newVal = appendElem(newS)
+
+		pointers, err := mq.pointers(newVal)
+		if err != nil {
+			return err
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Check if we can set the model, if we can, insert newslice
+	if value.CanSet() {
+		value.Set(newS)
+	}
+
+	return rows.Close()
+}
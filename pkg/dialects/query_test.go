@@ -0,0 +1,76 @@
+package dialects
+
+import (
+	"testing"
+)
+
+func TestModelQuery(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	for i, name := range []string{"Carl", "Bob", "Alice", "Dave"} {
+		if _, err := db.Create(&testUser{Name: name, Age: 10 * (i + 1)}); err != nil {
+			t.Fatalf("error creating user. error: %v", err.Error())
+		}
+	}
+
+	tests := map[string]struct {
+		query func(users *testUsers) error
+		want  []string
+	}{
+		"Test where chaining": {
+			query: func(users *testUsers) error {
+				return db.Model(users).Where("age > ?", 10).Where("name != ?", "Dave").Order("age asc").All()
+			},
+			want: []string{"Bob", "Alice"},
+		},
+		"Test limit and offset": {
+			query: func(users *testUsers) error {
+				return db.Model(users).Order("age desc").Limit(2).Offset(1).All()
+			},
+			want: []string{"Alice", "Bob"},
+		},
+		"Test select columns": {
+			query: func(users *testUsers) error {
+				return db.Model(users).Select("name").Where("age = ?", 40).All()
+			},
+			want: []string{"Dave"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			users := new(testUsers)
+			if err := test.query(users); err != nil {
+				t.Fatalf("error executing query. error: %v", err.Error())
+			}
+
+			if len(*users) != len(test.want) {
+				t.Fatalf("Wanted: %v - Have: %v", test.want, *users)
+			}
+
+			for i, u := range *users {
+				if u.Name != test.want[i] {
+					t.Fatalf("Wanted: %v - Have: %v", test.want, *users)
+				}
+			}
+		})
+	}
+
+	count, err := db.Model(new(testUsers)).Where("age >= ?", 20).Count()
+	if err != nil {
+		t.Fatalf("error counting users. error: %v", err.Error())
+	}
+
+	if count != 3 {
+		t.Fatalf("Wanted: 3 - Have: %d", count)
+	}
+
+	user := new(testUser)
+	if err := db.Model(user).Where("name = ?", "Alice").First(); err != nil {
+		t.Fatalf("error finding first user. error: %v", err.Error())
+	}
+
+	if user.Age != 30 {
+		t.Fatalf("Wanted: 30 - Have: %d", user.Age)
+	}
+}
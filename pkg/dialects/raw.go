@@ -1,6 +1,7 @@
 package dialects
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"reflect"
@@ -9,31 +10,43 @@ import (
 	"github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
 )
 
+// RawQuery holds the context given to RawContext, used by Exec and All
 type RawQuery struct {
-	stmt  *sql.Stmt
+	ctx   context.Context
+	db    Executor
 	args  []any
 	query string
 }
 
 // Executes given query strig to perform which ever action the query denotes
-func (rq *RawQuery) Exec() error {
-	result, err := rq.stmt.Exec(rq.args...)
+func (rq *RawQuery) Exec() (Result, error) {
+	return rq.ExecContext(rq.ctx)
+}
+
+// ExecContext executes the query, the given context is used for the duration of the execution
+func (rq *RawQuery) ExecContext(ctx context.Context) (Result, error) {
+	r, err := rq.db.ExecContext(ctx, rq.query, rq.args...)
 
 	if err != nil {
-		return logger.Log.LogError("error occurred executing raw query.", err)
+		return Result{}, logger.Log.LogError("error occurred executing raw query.", err)
 	}
 
-	rows, err := result.RowsAffected()
+	result, err := newResult(r)
 	if err != nil {
-		return logger.Log.LogError("error occurred calculating affected rows from raw query.", err)
+		return result, logger.Log.LogError("error occurred calculating affected rows from raw query.", err)
 	}
-	logger.Log.LogEvent("warn", "executed raw query", "rows affected", rows)
+	logger.Log.LogEvent("warn", "executed raw query", "rows affected", result.RowsAffected)
 
-	return nil
+	return result, nil
 }
 
 // All will accept a model, perform the query, and attempt to fill any data values into the given model.
 func (rq *RawQuery) All(model any) error {
+	return rq.AllContext(rq.ctx, model)
+}
+
+// AllContext performs the same operations as All, the given context is used for querying the rows
+func (rq *RawQuery) AllContext(ctx context.Context, model any) error {
 	var rows *sql.Rows
 	var err error
 	m := reflect.Indirect(reflect.ValueOf(model))
@@ -42,17 +55,9 @@ func (rq *RawQuery) All(model any) error {
 			return logger.Log.LogError("you must pass a pointer to a struct for all to function", errors.New("in correct value passed. Must be pointer"))
 		}
 
-		if len(rq.args) > 0 {
-			rows, err = rq.stmt.Query(rq.args...)
-			if err != nil {
-				return logger.Log.LogError("error occurred executing raw query", err)
-			}
-		} else {
-			rows, err = rq.stmt.Query()
-			if err != nil {
-				return logger.Log.LogError("error occurred executing raw query", err)
-			}
-
+		rows, err = rq.db.QueryContext(ctx, rq.query, rq.args...)
+		if err != nil {
+			return logger.Log.LogError("error occurred executing raw query", err)
 		}
 
 		defer func() {
@@ -65,17 +70,15 @@ func (rq *RawQuery) All(model any) error {
 		newS := reflect.MakeSlice(reflect.SliceOf(m.Type().Elem()), 0, 0)
 
 		for rows.Next() {
-			// Create new pointer to inner struct type
-			newVal := reflect.New(m.Type().Elem())
+			// Grow the slice by a new element of the inner struct type
+			var newVal reflect.Value
+			newS, newVal = appendElem(newS)
 
 			// Fill model with data after parsing out attributes for struct
 			err := rows.Scan(sqlbuilder.PointerAttributes(newVal)...)
 			if err != nil {
 				return err
 			}
-
-			// Append slice of new val
-			newS = reflect.Append(newS, newVal.Elem())
 		}
 
 		// Ensure rows did not encounter an error when calling Next()
@@ -96,7 +99,7 @@ func (rq *RawQuery) All(model any) error {
 		return logger.Log.LogError("you must pass a pointer to a struct for all to function", errors.New("in correct value passed. Must be pointer"))
 	}
 
-	row := rq.stmt.QueryRow(rq.args...)
+	row := rq.db.QueryRowContext(ctx, rq.query, rq.args...)
 	if err := row.Scan(sqlbuilder.PointerAttributes(reflect.ValueOf(model))...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return err
@@ -0,0 +1,75 @@
+package dialects
+
+import (
+	"database/sql"
+	"reflect"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
+)
+
+// Result is returned from the write operations, i.e. Create, Update and Delete
+type Result struct {
+	// RowsAffected is the amount of rows inserted, updated or deleted
+	RowsAffected int64
+	// LastInsertId is the id generated by the database for the inserted row, i.e. an auto increment id.
+	// Postgres does not support LastInsertId, the id is read back from the created row instead.
+	// LastInsertId is 0 when no id was generated by the database.
+	LastInsertId int64
+}
+
+// Converts the sql.Result of the driver, drivers not supporting LastInsertId leave it as 0
+func newResult(result sql.Result) (Result, error) {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return Result{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		id = 0
+	}
+
+	return Result{RowsAffected: rows, LastInsertId: id}, nil
+}
+
+// Returns the integer id attribute of the model when it is empty, the id is then generated by the database.
+func generatedID(model any) (reflect.Value, bool) {
+	value := reflect.Indirect(reflect.ValueOf(model))
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	f, found := sqlbuilder.FieldByColumn(value.Type(), "id")
+	if !found {
+		return reflect.Value{}, false
+	}
+
+	id := value.FieldByIndex(f.Index)
+	switch id.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return id, id.IsZero() && id.CanSet()
+	}
+
+	return reflect.Value{}, false
+}
+
+// Sets the id generated by the database on the id attribute
+func setGeneratedID(id reflect.Value, generated int64) {
+	switch id.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		id.SetUint(uint64(generated))
+	default:
+		id.SetInt(generated)
+	}
+}
+
+// Returns the value of the integer id attribute
+func intID(id reflect.Value) int64 {
+	switch id.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(id.Uint())
+	default:
+		return id.Int()
+	}
+}
@@ -0,0 +1,68 @@
+package dialects
+
+import (
+	"testing"
+)
+
+type counter struct {
+	ID   int64
+	Name string
+}
+
+func TestResult(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&counter{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	first, second := &counter{Name: "first"}, &counter{Name: "second"}
+	for i, c := range []*counter{first, second} {
+		result, err := db.Create(c)
+		if err != nil {
+			t.Fatalf("error creating counter. error: %v", err.Error())
+		}
+
+		if result.RowsAffected != 1 || result.LastInsertId != int64(i+1) || c.ID != int64(i+1) {
+			t.Fatalf("Wanted: 1 row with id %d - Have: %+v with id %d", i+1, result, c.ID)
+		}
+	}
+
+	tests := map[string]struct {
+		write func() (Result, error)
+		want  int64
+	}{
+		"update":           {write: func() (Result, error) { first.Name = "updated"; return db.Update(first) }, want: 1},
+		"update no match":  {write: func() (Result, error) { return db.Update(&counter{ID: 100, Name: "missing"}) }, want: 0},
+		"delete":           {write: func() (Result, error) { return db.Delete(second) }, want: 1},
+		"delete no match":  {write: func() (Result, error) { return db.Delete(&counter{ID: 100}) }, want: 0},
+		"create many":      {write: func() (Result, error) { return db.CreateMany(&[]counter{{Name: "a"}, {Name: "b"}}) }, want: 2},
+		"raw exec":         {write: func() (Result, error) { return rawExec(t, db, "UPDATE counters SET name = ?", "raw") }, want: 3},
+		"raw exec nothing": {write: func() (Result, error) { return rawExec(t, db, "DELETE FROM counters WHERE id = ?", 100) }, want: 0},
+	}
+
+	for _, name := range []string{"update", "update no match", "delete", "delete no match", "create many", "raw exec", "raw exec nothing"} {
+		test := tests[name]
+		t.Run(name, func(t *testing.T) {
+			result, err := test.write()
+			if err != nil {
+				t.Fatalf("error writing counters. error: %v", err.Error())
+			}
+
+			if result.RowsAffected != test.want {
+				t.Fatalf("Wanted: %d - Have: %d", test.want, result.RowsAffected)
+			}
+		})
+	}
+}
+
+func rawExec(t *testing.T, db DialectHandler, query string, args ...any) (Result, error) {
+	t.Helper()
+
+	q, err := db.Raw(query, args...)
+	if err != nil {
+		t.Fatalf("error building raw query. error: %v", err.Error())
+	}
+
+	return q.Exec()
+}
@@ -0,0 +1,114 @@
+package dialects
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/logger"
+	"github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
+)
+
+// Queries used to introspect the columns of an existing table per dialect
+var tableColumnQueries = map[string]string{
+	DIALECT_TYPE_PSQL:   "SELECT column_name, data_type FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1",
+	DIALECT_TYPE_MYSQL:  "SELECT column_name, column_type FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ?",
+	DIALECT_TYPE_SQLITE: "SELECT name, type FROM pragma_table_info(?)",
+}
+
+// AutoMigrate creates the table for each model if it does not exist, along with the join tables of any many to many relations.
+// If the table does exist, any columns present on the model that are missing from the table are added.
+// Existing columns are never altered or dropped.
+func AutoMigrate(ctx context.Context, db Executor, dialectType string, models ...any) error {
+	for _, model := range models {
+		modelType := sqlbuilder.ModelType(model)
+		tableName := sqlbuilder.TableName(modelType)
+
+		existing, err := TableColumns(ctx, db, dialectType, tableName)
+		if err != nil {
+			return err
+		}
+
+		if err := createJoinTables(ctx, db, dialectType, modelType); err != nil {
+			return err
+		}
+
+		if len(existing) == 0 {
+			if _, err := db.ExecContext(ctx, sqlbuilder.CreateTableQuery(modelType, dialectType)); err != nil {
+				return fmt.Errorf("error creating table %s. Error: %w", tableName, err)
+			}
+
+			continue
+		}
+
+		for _, c := range sqlbuilder.Columns(modelType, dialectType) {
+			if _, found := existing[c.Name]; found {
+				continue
+			}
+
+			if c.PrimaryKey {
+				logger.Log.LogEvent("warn", "primary key column is missing from existing table and will not be added", "table", tableName, "column", c.Name)
+
+				continue
+			}
+
+			if _, err := db.ExecContext(ctx, sqlbuilder.AddColumnQuery(tableName, c)); err != nil {
+				return fmt.Errorf("error adding column %s to table %s. Error: %w", c.Name, tableName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Creates the join tables of all many to many relations declared on the model
+func createJoinTables(ctx context.Context, db Executor, dialectType string, modelType reflect.Type) error {
+	relations, err := sqlbuilder.Relations(modelType)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range relations {
+		if r.Kind != sqlbuilder.MANY_TO_MANY {
+			continue
+		}
+
+		if _, err := db.ExecContext(ctx, sqlbuilder.JoinTableQuery(modelType, r, dialectType)); err != nil {
+			return fmt.Errorf("error creating join table %s. Error: %w", r.JoinTable, err)
+		}
+	}
+
+	return nil
+}
+
+// TableColumns returns the column names mapped to the column types of the given table as reported by the database.
+// An empty map is returned if the table does not exist.
+func TableColumns(ctx context.Context, db Executor, dialectType string, tableName string) (map[string]string, error) {
+	columns := make(map[string]string)
+
+	rows, err := db.QueryContext(ctx, tableColumnQueries[dialectType], tableName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading columns for table %s. Error: %w", tableName, err)
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Log.LogError("error closing database rows.", err)
+		}
+	}()
+
+	for rows.Next() {
+		var name, columnType string
+		if err := rows.Scan(&name, &columnType); err != nil {
+			return nil, err
+		}
+
+		columns[name] = columnType
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, rows.Close()
+}
@@ -0,0 +1,58 @@
+package dialects
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type testVehicle struct {
+	ID     uuid.UUID
+	Color  string
+	Recall bool
+}
+
+type testVehicles []testVehicle
+
+func TestAutoMigrate(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	// Shadows the testUser model, adding the email attribute that is missing from the test_users table
+	type testUser struct {
+		ID    uuid.UUID
+		Name  string
+		Age   int
+		Email string
+	}
+
+	if err := db.AutoMigrate(&testVehicle{}, &testUser{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	// Migrating again should be a no-op
+	if err := db.AutoMigrate(&testVehicle{}, &testUser{}); err != nil {
+		t.Fatalf("error re-migrating models. error: %v", err.Error())
+	}
+
+	if _, err := db.Create(&testVehicle{Color: "Red", Recall: true}); err != nil {
+		t.Fatalf("error creating vehicle. error: %v", err.Error())
+	}
+
+	vehicles := new(testVehicles)
+	if err := db.Find(vehicles); err != nil || len(*vehicles) != 1 {
+		t.Fatalf("error finding vehicles. error: %v", err)
+	}
+
+	if _, err := db.Create(&testUser{Name: "Carl", Email: "carl@email.com"}); err != nil {
+		t.Fatalf("error creating user with added column. error: %v", err.Error())
+	}
+
+	user := new(testUser)
+	if err := db.Find(user); err != nil {
+		t.Fatalf("error finding user. error: %v", err.Error())
+	}
+
+	if user.Email != "carl@email.com" {
+		t.Fatalf("Wanted: carl@email.com - Have: %s", user.Email)
+	}
+}
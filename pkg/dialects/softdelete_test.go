@@ -0,0 +1,142 @@
+package dialects
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type account struct {
+	ID        uuid.UUID
+	Name      string
+	DeletedAt *time.Time
+}
+
+type archivedAccount struct {
+	ID         uuid.UUID
+	Name       string
+	ArchivedAt sql.NullTime `tinyorm:"soft_delete"`
+}
+
+func TestSoftDelete(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&account{}, &archivedAccount{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	carl, bob := &account{ID: uuid.New(), Name: "Carl"}, &account{ID: uuid.New(), Name: "Bob"}
+	for _, a := range []*account{carl, bob} {
+		if _, err := db.Create(a); err != nil {
+			t.Fatalf("error creating account. error: %v", err.Error())
+		}
+	}
+
+	if _, err := db.Delete(carl); err != nil {
+		t.Fatalf("error deleting account. error: %v", err.Error())
+	}
+
+	if carl.DeletedAt == nil {
+		t.Fatal("DeletedAt was not set on the model")
+	}
+
+	tests := map[string]struct {
+		find func(accounts *[]account) error
+		want int
+	}{
+		"find excludes deleted": {
+			find: func(accounts *[]account) error { return db.Find(accounts) },
+			want: 1,
+		},
+		"find unscoped": {
+			find: func(accounts *[]account) error { return db.Find(accounts, Unscoped()) },
+			want: 2,
+		},
+		"where excludes deleted": {
+			find: func(accounts *[]account) error { return db.Where(accounts, "name = ?", 0, "Carl") },
+			want: 0,
+		},
+		"where unscoped": {
+			find: func(accounts *[]account) error { return db.Where(accounts, "name = ?", 0, "Carl", Unscoped()) },
+			want: 1,
+		},
+		"builder excludes deleted": {
+			find: func(accounts *[]account) error { return db.Model(accounts).All() },
+			want: 1,
+		},
+		"builder unscoped": {
+			find: func(accounts *[]account) error { return db.Model(accounts).Unscoped().All() },
+			want: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			accounts := new([]account)
+			if err := test.find(accounts); err != nil {
+				t.Fatalf("error finding accounts. error: %v", err.Error())
+			}
+
+			if len(*accounts) != test.want {
+				t.Fatalf("Wanted: %d - Have: %d", test.want, len(*accounts))
+			}
+		})
+	}
+
+	t.Run("find by id", func(t *testing.T) {
+		if err := db.Find(&account{}, carl.ID); !errors.Is(err, sql.ErrNoRows) {
+			t.Fatalf("Wanted: %v - Have: %v", sql.ErrNoRows, err)
+		}
+
+		found := &account{}
+		if err := db.Find(found, carl.ID, Unscoped()); err != nil {
+			t.Fatalf("error finding account. error: %v", err.Error())
+		}
+
+		if found.DeletedAt == nil {
+			t.Fatal("DeletedAt was not scanned")
+		}
+	})
+
+	t.Run("hard delete", func(t *testing.T) {
+		if _, err := db.HardDelete(carl); err != nil {
+			t.Fatalf("error deleting account. error: %v", err.Error())
+		}
+
+		count, err := db.Model(new([]account)).Unscoped().Count()
+		if err != nil {
+			t.Fatalf("error counting accounts. error: %v", err.Error())
+		}
+
+		if count != 1 {
+			t.Fatalf("Wanted: 1 - Have: %d", count)
+		}
+	})
+
+	t.Run("soft delete tag", func(t *testing.T) {
+		archived := &archivedAccount{ID: uuid.New(), Name: "Old"}
+		if _, err := db.Create(archived); err != nil {
+			t.Fatalf("error creating account. error: %v", err.Error())
+		}
+
+		if _, err := db.Delete(archived); err != nil {
+			t.Fatalf("error deleting account. error: %v", err.Error())
+		}
+
+		if !archived.ArchivedAt.Valid {
+			t.Fatal("ArchivedAt was not set on the model")
+		}
+
+		count, err := db.Model(new([]archivedAccount)).Count()
+		if err != nil {
+			t.Fatalf("error counting accounts. error: %v", err.Error())
+		}
+
+		if count != 0 {
+			t.Fatalf("Wanted: 0 - Have: %d", count)
+		}
+	})
+}
@@ -1,84 +1,299 @@
 package dialects
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 
 	"github.com/BitlyTwiser/tinyORM/pkg/logger"
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 )
 
 const DIALECT_TYPE_SQLITE = "sqlite3"
 
+// SQLite versions prior to 3.32.0 limit a statement to 999 parameters
+func sqliteMaxParameters() int {
+	if _, version, _ := sqlite3.Version(); version < 3032000 {
+		return 999
+	}
+
+	return 32766
+}
+
+// SQLite supports RETURNING as of 3.35.0
+func sqliteSupportsReturning() bool {
+	_, version, _ := sqlite3.Version()
+
+	return version >= 3035000
+}
+
 type SQLite struct {
 	db     *sql.DB
-	mu     sync.Mutex
+	tx     *sql.Tx
+	txCtx  context.Context // Context the transaction or savepoint was started with
+	depth  int             // Nesting depth of the transaction, > 0 denotes a savepoint
+	stmts  *stmtCache
 	config DBConfig
 }
 
 var _ DialectHandler = (*SQLite)(nil)
 
-func (s *SQLite) Create(model any) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+func (s *SQLite) Create(model any) (Result, error) {
+	return s.CreateContext(context.Background(), model)
+}
+
+func (s *SQLite) CreateContext(ctx context.Context, model any) (Result, error) {
+	return Create(ctx, s.conn(), model, DIALECT_TYPE_SQLITE)
+}
+
+// CreateMany inserts all models of the slice using batched multi row INSERT statements
+func (s *SQLite) CreateMany(model any) (Result, error) {
+	return s.CreateManyContext(context.Background(), model)
+}
+
+func (s *SQLite) CreateManyContext(ctx context.Context, model any) (Result, error) {
+	return CreateMany(ctx, s.conn(), model, DIALECT_TYPE_SQLITE)
+}
+
+// Upsert inserts the model, updating the update columns of the existing row when the insert conflicts
+func (s *SQLite) Upsert(model any, conflictColumns []string, updateColumns []string) (Result, error) {
+	return s.UpsertContext(context.Background(), model, conflictColumns, updateColumns)
+}
+
+func (s *SQLite) UpsertContext(ctx context.Context, model any, conflictColumns []string, updateColumns []string) (Result, error) {
+	return Upsert(ctx, s.conn(), model, conflictColumns, updateColumns, DIALECT_TYPE_SQLITE)
+}
+
+func (s *SQLite) Update(model any, opts ...Option) (Result, error) {
+	return s.UpdateContext(context.Background(), model, opts...)
+}
+
+func (s *SQLite) UpdateContext(ctx context.Context, model any, opts ...Option) (Result, error) {
+	return Update(ctx, s.conn(), model, DIALECT_TYPE_SQLITE, opts...)
+}
+
+// UpdateAll writes every column of the model, including empty attributes
+func (s *SQLite) UpdateAll(model any) (Result, error) {
+	return s.UpdateAllContext(context.Background(), model)
+}
+
+func (s *SQLite) UpdateAllContext(ctx context.Context, model any) (Result, error) {
+	return UpdateAll(ctx, s.conn(), model, DIALECT_TYPE_SQLITE)
+}
+
+// UpdateMap sets the attributes of the model from the values keyed by column, then writes the columns
+func (s *SQLite) UpdateMap(model any, values map[string]any) (Result, error) {
+	return s.UpdateMapContext(context.Background(), model, values)
+}
+
+func (s *SQLite) UpdateMapContext(ctx context.Context, model any, values map[string]any) (Result, error) {
+	return UpdateMap(ctx, s.conn(), model, values, DIALECT_TYPE_SQLITE)
+}
+
+// UpdateWhere sets the columns of the values on every row matching the statement, the amount of rows updated is returned
+func (s *SQLite) UpdateWhere(model any, values map[string]any, stmt string, args ...any) (int64, error) {
+	return s.UpdateWhereContext(context.Background(), model, values, stmt, args...)
+}
+
+func (s *SQLite) UpdateWhereContext(ctx context.Context, model any, values map[string]any, stmt string, args ...any) (int64, error) {
+	return UpdateWhere(ctx, s.conn(), model, values, stmt, DIALECT_TYPE_SQLITE, args...)
+}
+
+func (s *SQLite) Delete(model any) (Result, error) {
+	return s.DeleteContext(context.Background(), model)
+}
+
+func (s *SQLite) DeleteContext(ctx context.Context, model any) (Result, error) {
+	return Delete(ctx, s.conn(), model, DIALECT_TYPE_SQLITE)
+}
+
+// HardDelete deletes the rows matching the model, ignoring any soft delete attribute
+func (s *SQLite) HardDelete(model any) (Result, error) {
+	return s.HardDeleteContext(context.Background(), model)
+}
+
+func (s *SQLite) HardDeleteContext(ctx context.Context, model any) (Result, error) {
+	return HardDelete(ctx, s.conn(), model, DIALECT_TYPE_SQLITE)
+}
 
-	return Create(s.db, model, DIALECT_TYPE_SQLITE)
+func (s *SQLite) BulkDelete(model any) (int64, error) {
+	return s.BulkDeleteContext(context.Background(), model)
 }
 
-func (s *SQLite) Update(model any) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+func (s *SQLite) BulkDeleteContext(ctx context.Context, model any) (int64, error) {
+	return BulkDelete(ctx, s.conn(), model, DIALECT_TYPE_SQLITE)
+}
 
-	return Update(s.db, model, DIALECT_TYPE_SQLITE)
+// DeleteAll deletes every row of the table of the model, ConfirmAll must be passed
+func (s *SQLite) DeleteAll(model any, confirm Confirmation) (int64, error) {
+	return s.DeleteAllContext(context.Background(), model, confirm)
 }
 
-func (s *SQLite) Delete(model any) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+func (s *SQLite) DeleteAllContext(ctx context.Context, model any, confirm Confirmation) (int64, error) {
+	return DeleteAll(ctx, s.conn(), model, confirm, DIALECT_TYPE_SQLITE)
+}
 
-	return Delete(s.db, model, DIALECT_TYPE_SQLITE)
+// DeleteWhere deletes every row matching the statement, the amount of rows deleted is returned
+func (s *SQLite) DeleteWhere(model any, stmt string, args ...any) (int64, error) {
+	return s.DeleteWhereContext(context.Background(), model, stmt, args...)
 }
 
-func (s *SQLite) BulkDelete(model any) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	return BulkDelete(s.db, model, DIALECT_TYPE_SQLITE)
+func (s *SQLite) DeleteWhereContext(ctx context.Context, model any, stmt string, args ...any) (int64, error) {
+	return DeleteWhere(ctx, s.conn(), model, stmt, DIALECT_TYPE_SQLITE, args...)
 }
 
 func (s *SQLite) Find(model any, args ...any) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	return s.FindContext(context.Background(), model, args...)
+}
 
-	return Find(s.db, model, DIALECT_TYPE_SQLITE, args...)
+func (s *SQLite) FindContext(ctx context.Context, model any, args ...any) error {
+	return Find(ctx, s.conn(), model, DIALECT_TYPE_SQLITE, args...)
 }
 
 func (s *SQLite) Where(model any, stmt string, limit int, args ...any) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	return s.WhereContext(context.Background(), model, stmt, limit, args...)
+}
 
-	return Where(s.db, model, stmt, limit, DIALECT_TYPE_SQLITE, args...)
+func (s *SQLite) WhereContext(ctx context.Context, model any, stmt string, limit int, args ...any) error {
+	return Where(ctx, s.conn(), model, stmt, limit, DIALECT_TYPE_SQLITE, args...)
 }
 
 func (s *SQLite) Raw(query string, args ...any) (*RawQuery, error) {
-	return Raw(s.db, query, args...)
+	return s.RawContext(context.Background(), query, args...)
+}
+
+func (s *SQLite) RawContext(ctx context.Context, query string, args ...any) (*RawQuery, error) {
+	return Raw(ctx, s.conn(), query, args...)
+}
+
+func (s *SQLite) Exec(query string, args ...any) error {
+	return s.ExecContext(context.Background(), query, args...)
+}
+
+func (s *SQLite) ExecContext(ctx context.Context, query string, args ...any) error {
+	return Exec(ctx, s.conn(), query, args...)
+}
+
+// Model starts a chainable query for the table of the given model
+func (s *SQLite) Model(model any) *ModelQuery {
+	return newModelQuery(s.conn(), model, DIALECT_TYPE_SQLITE)
+}
+
+// Association manages the join table rows of the named many to many relation of the model
+func (s *SQLite) Association(model any, name string) *Association {
+	return newAssociation(s.conn(), model, name, DIALECT_TYPE_SQLITE)
+}
+
+// AutoMigrate creates the tables for the given models, adding any missing columns to existing tables
+func (s *SQLite) AutoMigrate(models ...any) error {
+	return s.AutoMigrateContext(context.Background(), models...)
+}
+
+func (s *SQLite) AutoMigrateContext(ctx context.Context, models ...any) error {
+	return AutoMigrate(ctx, s.conn(), DIALECT_TYPE_SQLITE, models...)
+}
+
+func (s *SQLite) TableColumns(tableName string) (map[string]string, error) {
+	return s.TableColumnsContext(context.Background(), tableName)
+}
+
+func (s *SQLite) TableColumnsContext(ctx context.Context, tableName string) (map[string]string, error) {
+	return TableColumns(ctx, s.conn(), DIALECT_TYPE_SQLITE, tableName)
+}
+
+func (s *SQLite) Begin() (DialectHandler, error) {
+	return s.BeginTx(context.Background(), nil)
+}
+
+// BeginTx starts a transaction, the returned handler performs all actions within the transaction.
+// If the handler is already within a transaction, a savepoint is created instead and opts are ignored.
+func (s *SQLite) BeginTx(ctx context.Context, opts *sql.TxOptions) (DialectHandler, error) {
+	if s.tx != nil {
+		if err := savepoint(ctx, s.tx, s.depth+1); err != nil {
+			return nil, err
+		}
+
+		return &SQLite{db: s.db, tx: s.tx, txCtx: ctx, depth: s.depth + 1, stmts: s.stmts, config: s.config}, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLite{db: s.db, tx: tx, txCtx: ctx, stmts: s.stmts, config: s.config}, nil
+}
+
+func (s *SQLite) Commit() error {
+	return commit(s.txCtx, s.tx, s.depth)
+}
+
+func (s *SQLite) Rollback() error {
+	return rollback(s.txCtx, s.tx, s.depth)
+}
+
+func (s *SQLite) Transaction(fn func(tx DialectHandler) error) error {
+	return s.TransactionContext(context.Background(), fn)
+}
+
+func (s *SQLite) TransactionContext(ctx context.Context, fn func(tx DialectHandler) error) error {
+	return transaction(ctx, s, fn)
+}
+
+// conn returns the transaction if one has been started, else the database connection pool.
+// Queries are executed using the cached prepared statements of the handler.
+func (s *SQLite) conn() Executor {
+	return &cachedConn{db: s.db, tx: s.tx, stmts: s.stmts}
 }
 
 func (s *SQLite) SetDB(connDB *sql.DB) {
+	// Statements of the previous cache were prepared on the previous connection pool
+	if s.stmts != nil {
+		s.stmts.close(false)
+	}
+
 	s.db = connDB
+	s.stmts = newStmtCache(s.config.StatementCacheSize)
+}
+
+// Close closes the database connection pool and the cached prepared statements, waiting up to DEFAULT_CLOSE_TIMEOUT for in-flight queries to finish.
+// Handlers within a transaction cannot be closed, Close the handler the transaction was started from.
+func (s *SQLite) Close() error {
+	return closeWithTimeout(s)
+}
+
+// CloseContext performs the same as Close, waiting for in-flight queries until the context is done
+func (s *SQLite) CloseContext(ctx context.Context) error {
+	return closeHandler(ctx, s, s.db, s.tx, s.stmts)
 }
 
+// SetConfig sets the config of the handler.
+// The prepared statement cache is rebuilt when the statementCacheSize differs, closing the statements cached so far.
 func (s *SQLite) SetConfig(config DBConfig) {
+	resize := s.db != nil && s.tx == nil && config.StatementCacheSize != s.config.StatementCacheSize
 	s.config = config
+
+	if resize {
+		if s.stmts != nil {
+			s.stmts.close(false)
+		}
+
+		s.stmts = newStmtCache(config.StatementCacheSize)
+	}
 }
 
 func (s *SQLite) GetConfig() DBConfig {
 	return s.config
 }
 
+// Dialect returns the dialect type used when building queries
+func (s *SQLite) Dialect() string {
+	return DIALECT_TYPE_SQLITE
+}
+
 func (s *SQLite) QueryString() string {
 	var dsn strings.Builder
 
@@ -88,7 +303,7 @@ func (s *SQLite) QueryString() string {
 			logger.Log.LogError("Could not determine file path", fmt.Errorf("could not build filepath for creating sqlite database"))
 			return ""
 		}
-		logger.Log.LogEvent("warn", "no sqlite database found at given path! Attempting to create database now. (Please note, you will need to manually run migrations and create tables.)", map[string]any{"path": fullPath})
+		logger.Log.LogEvent("warn", "no sqlite database found at given path! Attempting to create database now. (Please note, you will need to run migrations or AutoMigrate to create tables.)", map[string]any{"path": fullPath})
 		if os.IsNotExist(err) {
 			if _, err := os.Create(fullPath); err != nil {
 				logger.Log.LogError("database file not found", fmt.Errorf("could not find sqlitedatabase and could not create the database file within project.. please create the SQLITE database"))
@@ -0,0 +1,267 @@
+package dialects
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/logger"
+)
+
+// Amount of prepared statements cached per handler when the statementCacheSize is not set within the database.yml
+const DEFAULT_STATEMENT_CACHE_SIZE = 100
+
+var errStmtCacheClosed = errors.New("the prepared statement cache has been closed")
+
+type unpreparedKey struct{}
+
+// Unprepared returns a context whose queries are executed without caching a prepared statement.
+// Use for one-off queries which would otherwise evict statements that are reused.
+// i.e. db.RawContext(dialects.Unprepared(ctx), "SELECT ...")
+func Unprepared(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unpreparedKey{}, true)
+}
+
+func unprepared(ctx context.Context) bool {
+	v, _ := ctx.Value(unpreparedKey{}).(bool)
+
+	return v
+}
+
+// stmtCache is a bounded least recently used cache of prepared statements keyed by the query.
+// Statements are reference counted, an evicted statement is only closed once every query using it has been released.
+type stmtCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // Front is the most recently used statement
+	entries map[string]*list.Element
+	closed  bool
+}
+
+type cachedStmt struct {
+	query   string
+	stmt    *sql.Stmt
+	refs    int
+	evicted bool
+}
+
+// newStmtCache returns a cache holding up to size statements, nil is returned when size is negative disabling the cache
+func newStmtCache(size int) *stmtCache {
+	if size < 0 {
+		return nil
+	}
+
+	if size == 0 {
+		size = DEFAULT_STATEMENT_CACHE_SIZE
+	}
+
+	return &stmtCache{size: size, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// get returns the prepared statement of the query, preparing and caching it when missing.
+// release must be called once the statement has been executed.
+func (c *stmtCache) get(ctx context.Context, db *sql.DB, query string) (*sql.Stmt, func(), error) {
+	if cs, found := c.lookup(query); found {
+		return cs.stmt, func() { c.release(cs) }, nil
+	}
+
+	// Prepared outside of the lock so a slow prepare does not hold up other queries
+	stmt, err := db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		closeStmt(stmt)
+
+		return nil, nil, errStmtCacheClosed
+	}
+
+	// Another query may have prepared the same statement in the meantime
+	if e, found := c.entries[query]; found {
+		closeStmt(stmt)
+		c.order.MoveToFront(e)
+		cs := e.Value.(*cachedStmt)
+		cs.refs++
+
+		return cs.stmt, func() { c.release(cs) }, nil
+	}
+
+	cs := &cachedStmt{query: query, stmt: stmt, refs: 1}
+	c.entries[query] = c.order.PushFront(cs)
+
+	for c.order.Len() > c.size {
+		c.evict(c.order.Back(), false)
+	}
+
+	return stmt, func() { c.release(cs) }, nil
+}
+
+func (c *stmtCache) lookup(query string) (*cachedStmt, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, found := c.entries[query]
+	if !found {
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+	cs := e.Value.(*cachedStmt)
+	cs.refs++
+
+	return cs, true
+}
+
+func (c *stmtCache) release(cs *cachedStmt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cs.refs--
+	if cs.evicted && cs.refs == 0 {
+		closeStmt(cs.stmt)
+	}
+}
+
+// Removes the statement from the cache, closing it unless it is in use. The lock must be held.
+// Unless wait is set the statement is closed in the background, as closing waits for any open rows of the statement.
+func (c *stmtCache) evict(e *list.Element, wait bool) {
+	cs := c.order.Remove(e).(*cachedStmt)
+	delete(c.entries, cs.query)
+	cs.evicted = true
+
+	if cs.refs > 0 {
+		return
+	}
+
+	if wait {
+		if err := cs.stmt.Close(); err != nil {
+			logger.Log.LogError("error closing prepared statement", err)
+		}
+
+		return
+	}
+
+	closeStmt(cs.stmt)
+}
+
+// close closes every cached statement, statements in use are closed once released.
+// Unless wait is set the statements are closed in the background, used when rows of the statements may still be open.
+func (c *stmtCache) close(wait bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	for c.order.Len() > 0 {
+		c.evict(c.order.Back(), wait)
+	}
+}
+
+// len returns the amount of cached statements
+func (c *stmtCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func closeStmt(stmt *sql.Stmt) {
+	go func() {
+		if err := stmt.Close(); err != nil {
+			logger.Log.LogError("error closing prepared statement", err)
+		}
+	}()
+}
+
+// cachedConn is the Executor of a handler, queries are executed using the prepared statements of the cache.
+// Within a transaction queries not already cached are executed unprepared and are not added to the cache.
+// Cached statements are bound to the transaction using Tx.StmtContext, creating a new transaction specific statement on every call.
+// The database re-prepares the statement on the connection of the transaction when it was prepared on another connection.
+type cachedConn struct {
+	db    *sql.DB
+	tx    *sql.Tx
+	stmts *stmtCache
+}
+
+func (c *cachedConn) executor() Executor {
+	if c.tx != nil {
+		return c.tx
+	}
+
+	return c.db
+}
+
+// Returns the statement of the query, or false when the query should not use the cache
+func (c *cachedConn) stmt(ctx context.Context, query string) (*sql.Stmt, func(), bool, error) {
+	if c.stmts == nil || unprepared(ctx) {
+		return nil, nil, false, nil
+	}
+
+	// Preparing on the pool needs a second connection whilst the transaction holds one, which deadlocks once the pool is exhausted.
+	// Within a transaction only statements already cached are used, bound to the connection of the transaction.
+	// Statements bound to the transaction are closed when the transaction ends.
+	if c.tx != nil {
+		cs, found := c.stmts.lookup(query)
+		if !found {
+			return nil, nil, false, nil
+		}
+
+		return c.tx.StmtContext(ctx, cs.stmt), func() { c.stmts.release(cs) }, true, nil
+	}
+
+	stmt, release, err := c.stmts.get(ctx, c.db, query)
+	if err != nil {
+		return nil, nil, true, err
+	}
+
+	return stmt, release, true, nil
+}
+
+// PrepareContext prepares a statement outside of the cache, the caller must close the statement
+func (c *cachedConn) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.executor().PrepareContext(ctx, query)
+}
+
+func (c *cachedConn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	stmt, release, cached, err := c.stmt(ctx, query)
+	if !cached {
+		return c.executor().ExecContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return stmt.ExecContext(ctx, args...)
+}
+
+func (c *cachedConn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	stmt, release, cached, err := c.stmt(ctx, query)
+	if !cached {
+		return c.executor().QueryContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return stmt.QueryContext(ctx, args...)
+}
+
+func (c *cachedConn) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	stmt, release, cached, err := c.stmt(ctx, query)
+	if !cached || err != nil {
+		// The error of preparing the statement is returned by the row when scanned
+		return c.executor().QueryRowContext(ctx, query, args...)
+	}
+	defer release()
+
+	return stmt.QueryRowContext(ctx, args...)
+}
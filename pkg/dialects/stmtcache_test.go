@@ -0,0 +1,221 @@
+package dialects
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStmtCache(t *testing.T) {
+	db := newSQLiteHandler(t).(*SQLite)
+	db.stmts = newStmtCache(2)
+	ctx := context.Background()
+
+	queries := []string{"SELECT 1", "SELECT 2", "SELECT 3"}
+	stmts := make(map[string]any)
+
+	for _, q := range queries[:2] {
+		stmt, release, err := db.stmts.get(ctx, db.db, q)
+		if err != nil {
+			t.Fatalf("error preparing statement. error: %v", err.Error())
+		}
+		release()
+		stmts[q] = stmt
+	}
+
+	t.Run("reuses statements", func(t *testing.T) {
+		stmt, release, err := db.stmts.get(ctx, db.db, "SELECT 1")
+		if err != nil {
+			t.Fatalf("error preparing statement. error: %v", err.Error())
+		}
+		release()
+
+		if stmt != stmts["SELECT 1"] {
+			t.Fatal("Wanted: the cached statement - Have: a new statement")
+		}
+	})
+
+	t.Run("evicts least recently used", func(t *testing.T) {
+		// SELECT 1 was used last, so SELECT 2 is evicted
+		_, release, err := db.stmts.get(ctx, db.db, "SELECT 3")
+		if err != nil {
+			t.Fatalf("error preparing statement. error: %v", err.Error())
+		}
+		release()
+
+		if db.stmts.len() != 2 {
+			t.Fatalf("Wanted: 2 - Have: %d", db.stmts.len())
+		}
+
+		if _, found := db.stmts.entries["SELECT 2"]; found {
+			t.Fatal("Wanted: SELECT 2 evicted - Have: SELECT 2 cached")
+		}
+	})
+
+	t.Run("evicted statements in use stay open", func(t *testing.T) {
+		stmt, release, err := db.stmts.get(ctx, db.db, "SELECT 1")
+		if err != nil {
+			t.Fatalf("error preparing statement. error: %v", err.Error())
+		}
+
+		for _, q := range []string{"SELECT 4", "SELECT 5"} {
+			_, r, err := db.stmts.get(ctx, db.db, q)
+			if err != nil {
+				t.Fatalf("error preparing statement. error: %v", err.Error())
+			}
+			r()
+		}
+
+		if _, err := stmt.Exec(); err != nil {
+			t.Fatalf("error executing evicted statement. error: %v", err.Error())
+		}
+		release()
+
+		// Released statements are closed in the background
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			if _, err := stmt.Exec(); err != nil {
+				break
+			}
+
+			if time.Now().After(deadline) {
+				t.Fatal("evicted statement was not closed after release")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+}
+
+func TestHandlerStmtCache(t *testing.T) {
+	db := newSQLiteHandler(t).(*SQLite)
+	createTestUsers(t, db, 2)
+
+	tests := map[string]struct {
+		run    func() error
+		cached bool
+	}{
+		"find": {
+			run:    func() error { return db.Find(new(testUsers)) },
+			cached: true,
+		},
+		// Transactions only use statements which are already cached
+		"transaction": {
+			run: func() error {
+				return db.Transaction(func(tx DialectHandler) error {
+					return tx.Model(new(testUsers)).Where("age > ?", 0).All()
+				})
+			},
+			cached: false,
+		},
+		"unprepared": {
+			run: func() error {
+				q, err := db.RawContext(Unprepared(context.Background()), "SELECT id, name, age FROM test_users WHERE age > ?", 0)
+				if err != nil {
+					return err
+				}
+
+				return q.All(new(testUsers))
+			},
+			cached: false,
+		},
+	}
+
+	for _, name := range []string{"find", "transaction", "unprepared"} {
+		test := tests[name]
+		t.Run(name, func(t *testing.T) {
+			before := db.stmts.len()
+			if err := test.run(); err != nil {
+				t.Fatalf("error running query. error: %v", err.Error())
+			}
+
+			if cached := db.stmts.len() > before; cached != test.cached {
+				t.Fatalf("Wanted cached: %v - Have: %v", test.cached, cached)
+			}
+		})
+	}
+
+	t.Run("close", func(t *testing.T) {
+		tx, err := db.Begin()
+		if err != nil {
+			t.Fatalf("error beginning transaction. error: %v", err.Error())
+		}
+
+		if err := tx.Close(); err == nil {
+			t.Fatal("expected an error closing a handler within a transaction")
+		}
+
+		if err := tx.Rollback(); err != nil {
+			t.Fatalf("error rolling back transaction. error: %v", err.Error())
+		}
+
+		if err := db.Close(); err != nil {
+			t.Fatalf("error closing handler. error: %v", err.Error())
+		}
+
+		if db.stmts.len() != 0 {
+			t.Fatalf("Wanted: 0 - Have: %d", db.stmts.len())
+		}
+
+		if err := db.Find(new(testUsers)); err == nil {
+			t.Fatal("expected an error querying a closed handler")
+		}
+	})
+}
+
+func TestSetDBClosesStmtCache(t *testing.T) {
+	db := newSQLiteHandler(t).(*SQLite)
+
+	if err := db.Find(new(testUsers)); err != nil {
+		t.Fatalf("error finding users. error: %v", err.Error())
+	}
+
+	previous := db.stmts
+	if previous.len() == 0 {
+		t.Fatal("Wanted: cached statements - Have: none")
+	}
+
+	db.SetDB(db.db)
+
+	if previous.len() != 0 || !previous.closed {
+		t.Fatalf("Wanted: previous cache closed - Have: %d statements cached", previous.len())
+	}
+
+	if err := db.Find(new(testUsers)); err != nil {
+		t.Fatalf("error finding users. error: %v", err.Error())
+	}
+}
+
+func TestSetConfigResizesStmtCache(t *testing.T) {
+	db := newSQLiteHandler(t).(*SQLite)
+
+	if err := db.Find(new(testUsers)); err != nil {
+		t.Fatalf("error finding users. error: %v", err.Error())
+	}
+
+	previous := db.stmts
+	config := db.GetConfig()
+
+	// The cache is kept when the size is unchanged
+	db.SetConfig(config)
+	if db.stmts != previous || previous.len() == 0 {
+		t.Fatal("Wanted: cache kept - Have: cache rebuilt")
+	}
+
+	config.StatementCacheSize = 1
+	db.SetConfig(config)
+
+	if !previous.closed || db.stmts == nil || db.stmts.size != 1 {
+		t.Fatal("Wanted: cache rebuilt with size 1 - Have: previous cache kept")
+	}
+
+	config.StatementCacheSize = -1
+	db.SetConfig(config)
+
+	if db.stmts != nil {
+		t.Fatal("Wanted: cache disabled - Have: cache kept")
+	}
+
+	if err := db.Find(new(testUsers)); err != nil {
+		t.Fatalf("error finding users. error: %v", err.Error())
+	}
+}
@@ -0,0 +1,49 @@
+package dialects
+
+import (
+	"database/sql"
+	"reflect"
+	"time"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
+)
+
+// Columns of the timestamps maintained by Create and Update
+const (
+	CREATED_AT = "created_at"
+	UPDATED_AT = "updated_at"
+)
+
+// Timestamps are stored in UTC so they are read back the same regardless of the column type storing the time zone
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+// Sets the time attribute of a model. time.Time, *time.Time and sql.NullTime attributes are supported.
+func setTime(field reflect.Value, t time.Time) {
+	switch field.Interface().(type) {
+	case time.Time:
+		field.Set(reflect.ValueOf(t))
+	case *time.Time:
+		field.Set(reflect.ValueOf(&t))
+	case sql.NullTime:
+		field.Set(reflect.ValueOf(sql.NullTime{Time: t, Valid: true}))
+	}
+}
+
+// Sets the time attribute of the model mapped to the column. Attributes already holding a time are only set when overwrite is true.
+func setTimestamp(model any, column string, t time.Time, overwrite bool) {
+	value := reflect.Indirect(reflect.ValueOf(model))
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	field, found := sqlbuilder.TimeField(value.Type(), column)
+	if !found {
+		return
+	}
+
+	if f := value.FieldByIndex(field.Index); overwrite || f.IsZero() {
+		setTime(f, t)
+	}
+}
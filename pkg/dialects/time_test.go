@@ -0,0 +1,77 @@
+package dialects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type post struct {
+	ID          uuid.UUID
+	Title       string
+	PublishedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func TestTimestamps(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&post{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	p := &post{ID: uuid.New(), Title: "First"}
+	if _, err := db.Create(p); err != nil {
+		t.Fatalf("error creating post. error: %v", err.Error())
+	}
+
+	if p.CreatedAt.IsZero() || !p.CreatedAt.Equal(p.UpdatedAt) {
+		t.Fatalf("Wanted: equal created and updated times - Have: %v and %v", p.CreatedAt, p.UpdatedAt)
+	}
+
+	found := &post{}
+	if err := db.Find(found, p.ID); err != nil {
+		t.Fatalf("error finding post. error: %v", err.Error())
+	}
+
+	if !found.CreatedAt.Equal(p.CreatedAt) || found.PublishedAt != nil {
+		t.Fatalf("Wanted: %v and nil - Have: %v and %v", p.CreatedAt, found.CreatedAt, found.PublishedAt)
+	}
+
+	published := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	found.Title, found.PublishedAt = "Updated", &published
+	if _, err := db.Update(found); err != nil {
+		t.Fatalf("error updating post. error: %v", err.Error())
+	}
+
+	updated := &post{}
+	if err := db.Model(updated).Where("id = ?", p.ID).First(); err != nil {
+		t.Fatalf("error finding post. error: %v", err.Error())
+	}
+
+	if !updated.CreatedAt.Equal(p.CreatedAt) || !updated.UpdatedAt.After(p.UpdatedAt) {
+		t.Fatalf("Wanted: created at %v and a later updated at - Have: %v and %v", p.CreatedAt, updated.CreatedAt, updated.UpdatedAt)
+	}
+
+	if updated.PublishedAt == nil || !updated.PublishedAt.Equal(published) {
+		t.Fatalf("Wanted: %v - Have: %v", published, updated.PublishedAt)
+	}
+
+	t.Run("null timestamps", func(t *testing.T) {
+		id := uuid.New()
+		if err := db.Exec("INSERT INTO posts (id, title) VALUES (?, ?)", id, "Null"); err != nil {
+			t.Fatalf("error inserting post. error: %v", err.Error())
+		}
+
+		nulls := &post{}
+		if err := db.Find(nulls, id); err != nil {
+			t.Fatalf("error finding post. error: %v", err.Error())
+		}
+
+		if !nulls.CreatedAt.IsZero() || nulls.PublishedAt != nil {
+			t.Fatalf("Wanted: zero times - Have: %v and %v", nulls.CreatedAt, nulls.PublishedAt)
+		}
+	})
+}
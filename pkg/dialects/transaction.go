@@ -0,0 +1,86 @@
+package dialects
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var ErrNoTransaction = errors.New("handler is not within a transaction")
+
+// transaction begins a transaction on the given handler and passes the transactional handler to fn.
+// If fn returns nil the transaction is committed, if fn returns an error or panics the transaction is rolled back.
+// If the handler is already within a transaction, a savepoint is used so only the work within fn is rolled back.
+// A panic is re-raised after the rollback has occurred.
+func transaction(ctx context.Context, handler DialectHandler, fn func(tx DialectHandler) error) (err error) {
+	tx, err := handler.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			// The panic takes priority over any rollback error
+			_ = tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w. error rolling back transaction: %v", err, rbErr)
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// savepoint creates a savepoint within the transaction for the given nesting depth.
+// The same SAVEPOINT syntax is supported by Postgres, MySQL and SQLite.
+func savepoint(ctx context.Context, tx *sql.Tx, depth int) error {
+	_, err := tx.ExecContext(ctx, "SAVEPOINT "+savepointName(depth))
+
+	return err
+}
+
+// commit will commit the transaction, or release the savepoint if the handler is a nested transaction
+func commit(ctx context.Context, tx *sql.Tx, depth int) error {
+	if tx == nil {
+		return ErrNoTransaction
+	}
+
+	if depth > 0 {
+		_, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepointName(depth))
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// rollback will rollback the transaction, or rollback to the savepoint if the handler is a nested transaction.
+// The savepoint is released after rolling back so the outer transaction may continue.
+func rollback(ctx context.Context, tx *sql.Tx, depth int) error {
+	if tx == nil {
+		return ErrNoTransaction
+	}
+
+	if depth > 0 {
+		if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepointName(depth)); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepointName(depth))
+
+		return err
+	}
+
+	return tx.Rollback()
+}
+
+func savepointName(depth int) string {
+	return fmt.Sprintf("tinyorm_savepoint_%d", depth)
+}
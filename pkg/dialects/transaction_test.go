@@ -0,0 +1,239 @@
+package dialects
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type testUser struct {
+	ID   uuid.UUID
+	Name string
+	Age  int
+}
+
+type testUsers []testUser
+
+// newSQLiteHandler creates a SQLite handler backed by a fresh database file with the test_users table created
+func newSQLiteHandler(t testing.TB) DialectHandler {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "tinyorm.db"))
+	if err != nil {
+		t.Fatalf("error opening sqlite database. error: %v", err.Error())
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := db.Exec("CREATE TABLE test_users (id TEXT PRIMARY KEY, name TEXT, age INTEGER)"); err != nil {
+		t.Fatalf("error creating test_users table. error: %v", err.Error())
+	}
+
+	handler := &SQLite{}
+	handler.SetDB(db)
+
+	return handler
+}
+
+func countUsers(t *testing.T, db DialectHandler) int {
+	t.Helper()
+
+	users := new(testUsers)
+	if err := db.Find(users); err != nil {
+		t.Fatalf("error finding users. error: %v", err.Error())
+	}
+
+	return len(*users)
+}
+
+func TestTransaction(t *testing.T) {
+	errAbort := errors.New("abort")
+
+	tests := map[string]struct {
+		fn        func(tx DialectHandler) error
+		wantErr   error
+		wantPanic bool
+		wantCount int
+	}{
+		"Test commit on nil error": {
+			fn: func(tx DialectHandler) error {
+				if _, err := tx.Create(&testUser{Name: "Carl"}); err != nil {
+					return err
+				}
+
+				_, err := tx.Create(&testUser{Name: "Bob"})
+
+				return err
+			},
+			wantCount: 2,
+		},
+		"Test rollback on error": {
+			fn: func(tx DialectHandler) error {
+				if _, err := tx.Create(&testUser{Name: "Carl"}); err != nil {
+					return err
+				}
+
+				return errAbort
+			},
+			wantErr: errAbort,
+		},
+		"Test nested rollback only undoes inner work": {
+			fn: func(tx DialectHandler) error {
+				if _, err := tx.Create(&testUser{Name: "Carl"}); err != nil {
+					return err
+				}
+
+				err := tx.Transaction(func(nested DialectHandler) error {
+					if _, err := nested.Create(&testUser{Name: "Bob"}); err != nil {
+						return err
+					}
+
+					return errAbort
+				})
+				if !errors.Is(err, errAbort) {
+					return errors.New("expected nested transaction to return the abort error")
+				}
+
+				return tx.Transaction(func(nested DialectHandler) error {
+					_, err := nested.Create(&testUser{Name: "Alice"})
+
+					return err
+				})
+			},
+			wantCount: 2,
+		},
+		"Test outer rollback undoes committed nested work": {
+			fn: func(tx DialectHandler) error {
+				if err := tx.Transaction(func(nested DialectHandler) error {
+					_, err := nested.Create(&testUser{Name: "Bob"})
+
+					return err
+				}); err != nil {
+					return err
+				}
+
+				return errAbort
+			},
+			wantErr: errAbort,
+		},
+		"Test rollback on panic": {
+			fn: func(tx DialectHandler) error {
+				if _, err := tx.Create(&testUser{Name: "Carl"}); err != nil {
+					return err
+				}
+
+				panic("abort")
+			},
+			wantPanic: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			db := newSQLiteHandler(t)
+
+			func() {
+				defer func() {
+					if r := recover(); (r != nil) != test.wantPanic {
+						t.Fatalf("unexpected panic state. recovered: %v", r)
+					}
+				}()
+
+				if err := db.Transaction(test.fn); !errors.Is(err, test.wantErr) {
+					t.Fatalf("Wanted: %v - Have: %v", test.wantErr, err)
+				}
+			}()
+
+			if count := countUsers(t, db); count != test.wantCount {
+				t.Fatalf("Wanted: %d users - Have: %d", test.wantCount, count)
+			}
+		})
+	}
+}
+
+func TestBeginCommitRollback(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.Commit(); !errors.Is(err, ErrNoTransaction) {
+		t.Fatalf("Wanted: %v - Have: %v", ErrNoTransaction, err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("error beginning transaction. error: %v", err.Error())
+	}
+
+	if _, err := tx.Create(&testUser{Name: "Carl"}); err != nil {
+		t.Fatalf("error creating user. error: %v", err.Error())
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("error rolling back transaction. error: %v", err.Error())
+	}
+
+	if count := countUsers(t, db); count != 0 {
+		t.Fatalf("Wanted: 0 users - Have: %d", count)
+	}
+}
+
+func TestTransactionSingleConnection(t *testing.T) {
+	db := newSQLiteHandler(t).(*SQLite)
+	db.db.SetMaxOpenConns(1)
+
+	// Cache the statement of Find so the transaction binds the cached statement to its connection
+	if err := db.Find(new(testUsers)); err != nil {
+		t.Fatalf("error finding users. error: %v", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := db.TransactionContext(ctx, func(tx DialectHandler) error {
+		if _, err := tx.CreateContext(ctx, &testUser{Name: "Carl"}); err != nil {
+			return err
+		}
+
+		return tx.FindContext(ctx, new(testUsers))
+	})
+	if err != nil {
+		t.Fatalf("error running transaction. error: %v", err.Error())
+	}
+
+	if count := countUsers(t, db); count != 1 {
+		t.Fatalf("Wanted: 1 users - Have: %d", count)
+	}
+}
+
+func TestSavepointContext(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("error beginning transaction. error: %v", err.Error())
+	}
+	defer tx.Rollback()
+
+	for name, end := range map[string]func(DialectHandler) error{
+		"commit":   func(nested DialectHandler) error { return nested.Commit() },
+		"rollback": func(nested DialectHandler) error { return nested.Rollback() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			nested, err := tx.BeginTx(ctx, nil)
+			if err != nil {
+				t.Fatalf("error creating savepoint. error: %v", err.Error())
+			}
+
+			// The savepoint is released using the context it was created with
+			cancel()
+			if err := end(nested); !errors.Is(err, context.Canceled) {
+				t.Fatalf("Wanted: %v - Have: %v", context.Canceled, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package dialects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestUpdateColumns(t *testing.T) {
+	tests := map[string]struct {
+		update func(db DialectHandler, user *testUser) (Result, error)
+		want   testUser
+	}{
+		"update skips empty attributes": {
+			update: func(db DialectHandler, user *testUser) (Result, error) {
+				user.Name, user.Age = "Bob", 0
+				return db.Update(user)
+			},
+			want: testUser{Name: "Bob", Age: 30},
+		},
+		"columns": {
+			update: func(db DialectHandler, user *testUser) (Result, error) {
+				user.Name, user.Age = "", 0
+				return db.Update(user, Columns("age"))
+			},
+			want: testUser{Name: "Carl", Age: 0},
+		},
+		"update all": {
+			update: func(db DialectHandler, user *testUser) (Result, error) {
+				user.Name, user.Age = "", 0
+				return db.UpdateAll(user)
+			},
+			want: testUser{Name: "", Age: 0},
+		},
+		"update map": {
+			update: func(db DialectHandler, user *testUser) (Result, error) {
+				return db.UpdateMap(user, map[string]any{"name": nil, "age": int64(12)})
+			},
+			want: testUser{Name: "", Age: 12},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			db := newSQLiteHandler(t)
+
+			user := &testUser{ID: uuid.New(), Name: "Carl", Age: 30}
+			if _, err := db.Create(user); err != nil {
+				t.Fatalf("error creating user. error: %v", err.Error())
+			}
+
+			if _, err := test.update(db, user); err != nil {
+				t.Fatalf("error updating user. error: %v", err.Error())
+			}
+
+			found := &testUser{}
+			if err := db.Find(found, user.ID); err != nil {
+				t.Fatalf("error finding user. error: %v", err.Error())
+			}
+
+			if found.Name != test.want.Name || found.Age != test.want.Age {
+				t.Fatalf("Wanted: %v - Have: %v", test.want, *found)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		db := newSQLiteHandler(t)
+		user := &testUser{ID: uuid.New(), Name: "Carl"}
+
+		if _, err := db.Update(user, Columns("email")); err == nil {
+			t.Fatal("expected an error for an unknown column")
+		}
+
+		if _, err := db.UpdateMap(user, map[string]any{"name": 1}); err == nil {
+			t.Fatal("expected an error for a mismatched value")
+		}
+
+		if _, err := db.UpdateAll(&testUser{Name: "Bob"}); err == nil {
+			t.Fatal("expected an error updating a model without an id")
+		}
+	})
+}
+
+func TestUpdateColumnsVersion(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&document{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	doc := &document{ID: uuid.New(), Title: "Draft"}
+	if _, err := db.Create(doc); err != nil {
+		t.Fatalf("error creating document. error: %v", err.Error())
+	}
+
+	stale := *doc
+	doc.Title = ""
+	if _, err := db.Update(doc, Columns("title")); err != nil {
+		t.Fatalf("error updating document. error: %v", err.Error())
+	}
+
+	if doc.Version != 2 {
+		t.Fatalf("Wanted: 2 - Have: %d", doc.Version)
+	}
+
+	if _, err := db.UpdateAll(&stale); err != ErrStaleObject {
+		t.Fatalf("Wanted: %v - Have: %v", ErrStaleObject, err)
+	}
+}
+
+func TestUpdateWhere(t *testing.T) {
+	db := newSQLiteHandler(t)
+	createTestUsers(t, db, 10)
+
+	updated, err := db.UpdateWhere(&testUser{}, map[string]any{"age": 0, "name": "minor"}, "age >= ?", 5)
+	if err != nil {
+		t.Fatalf("error updating users. error: %v", err.Error())
+	}
+
+	if updated != 5 {
+		t.Fatalf("Wanted: 5 updated - Have: %d", updated)
+	}
+
+	users := new(testUsers)
+	if err := db.Where(users, "name = ? AND age = ?", 0, "minor", 0); err != nil {
+		t.Fatalf("error finding users. error: %v", err.Error())
+	}
+
+	if len(*users) != 5 {
+		t.Fatalf("Wanted: 5 - Have: %d", len(*users))
+	}
+
+	tests := map[string]struct {
+		values map[string]any
+		stmt   string
+	}{
+		"empty statement": {values: map[string]any{"age": 1}},
+		"empty values":    {stmt: "age > ?"},
+		"unknown column":  {values: map[string]any{"email": ""}, stmt: "age > ?"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := db.UpdateWhere(&testUser{}, test.values, test.stmt, 1); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+
+	t.Run("updated at", func(t *testing.T) {
+		if err := db.AutoMigrate(&post{}); err != nil {
+			t.Fatalf("error migrating models. error: %v", err.Error())
+		}
+
+		p := &post{ID: uuid.New(), Title: "Draft"}
+		if _, err := db.Create(p); err != nil {
+			t.Fatalf("error creating post. error: %v", err.Error())
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		if _, err := db.UpdateWhere(&post{}, map[string]any{"title": "Published"}, "id = ?", p.ID); err != nil {
+			t.Fatalf("error updating posts. error: %v", err.Error())
+		}
+
+		found := &post{}
+		if err := db.Find(found, p.ID); err != nil {
+			t.Fatalf("error finding post. error: %v", err.Error())
+		}
+
+		if found.Title != "Published" || !found.UpdatedAt.After(p.UpdatedAt) {
+			t.Fatalf("Wanted: Published updated after %v - Have: %s %v", p.UpdatedAt, found.Title, found.UpdatedAt)
+		}
+	})
+}
+
+func TestUpdateGivenUpdatedAt(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&post{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	given := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// Both APIs write an updated_at given within the values as is
+	tests := map[string]func(p *post) error{
+		"update map": func(p *post) error {
+			_, err := db.UpdateMap(p, map[string]any{"title": "Imported", "updated_at": given})
+
+			return err
+		},
+		"update where": func(p *post) error {
+			_, err := db.UpdateWhere(&post{}, map[string]any{"title": "Imported", "updated_at": given}, "id = ?", p.ID)
+
+			return err
+		},
+	}
+
+	for name, update := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &post{ID: uuid.New(), Title: "Draft"}
+			if _, err := db.Create(p); err != nil {
+				t.Fatalf("error creating post. error: %v", err.Error())
+			}
+
+			if err := update(p); err != nil {
+				t.Fatalf("error updating post. error: %v", err.Error())
+			}
+
+			found := &post{}
+			if err := db.Find(found, p.ID); err != nil {
+				t.Fatalf("error finding post. error: %v", err.Error())
+			}
+
+			if found.Title != "Imported" || !found.UpdatedAt.Equal(given) {
+				t.Fatalf("Wanted: Imported updated at %v - Have: %s updated at %v", given, found.Title, found.UpdatedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package dialects
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestUpsert(t *testing.T) {
+	db := newSQLiteHandler(t)
+	id := uuid.New()
+
+	tests := map[string]struct {
+		user   *testUser
+		update []string
+		want   testUser
+	}{
+		"insert":         {user: &testUser{ID: id, Name: "Carl", Age: 30}, update: []string{"name", "age"}, want: testUser{ID: id, Name: "Carl", Age: 30}},
+		"update columns": {user: &testUser{ID: id, Name: "Bob", Age: 40}, update: []string{"name"}, want: testUser{ID: id, Name: "Bob", Age: 30}},
+		"do nothing":     {user: &testUser{ID: id, Name: "Alice", Age: 50}, want: testUser{ID: id, Name: "Bob", Age: 30}},
+	}
+
+	for _, name := range []string{"insert", "update columns", "do nothing"} {
+		test := tests[name]
+		t.Run(name, func(t *testing.T) {
+			if _, err := db.Upsert(test.user, []string{"id"}, test.update); err != nil {
+				t.Fatalf("error upserting user. error: %v", err.Error())
+			}
+
+			found := &testUser{}
+			if err := db.Find(found, id); err != nil {
+				t.Fatalf("error finding user. error: %v", err.Error())
+			}
+
+			if *found != test.want {
+				t.Fatalf("Wanted: %v - Have: %v", test.want, *found)
+			}
+
+			if count := countUsers(t, db); count != 1 {
+				t.Fatalf("Wanted: 1 - Have: %d", count)
+			}
+		})
+	}
+}
+
+type tag struct {
+	ID   uuid.UUID
+	Name string
+	Uses int
+}
+
+func TestUpsertReadsExistingRow(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	q, err := db.Raw("CREATE TABLE tags (id TEXT PRIMARY KEY, name TEXT UNIQUE, uses INTEGER)")
+	if err != nil {
+		t.Fatalf("error building query. error: %v", err.Error())
+	}
+
+	if _, err := q.Exec(); err != nil {
+		t.Fatalf("error creating table. error: %v", err.Error())
+	}
+
+	tests := map[string]struct {
+		update []string
+		uses   int
+	}{
+		"update":     {update: []string{"uses"}, uses: 2},
+		"do nothing": {uses: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			existing := &tag{Name: name, Uses: 1}
+			if _, err := db.Upsert(existing, []string{"name"}, test.update); err != nil {
+				t.Fatalf("error upserting tag. error: %v", err.Error())
+			}
+
+			if existing.ID == uuid.Nil {
+				t.Fatal("Wanted: the generated id set on the model - Have: an empty id")
+			}
+
+			// The conflicting insert generates a new id, the model must hold the id of the existing row
+			conflicting := &tag{Name: name, Uses: 2}
+			if _, err := db.Upsert(conflicting, []string{"name"}, test.update); err != nil {
+				t.Fatalf("error upserting tag. error: %v", err.Error())
+			}
+
+			if conflicting.ID != existing.ID || conflicting.Uses != test.uses {
+				t.Fatalf("Wanted: %v with %d uses - Have: %v with %d uses", existing.ID, test.uses, conflicting.ID, conflicting.Uses)
+			}
+		})
+	}
+}
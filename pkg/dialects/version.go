@@ -0,0 +1,38 @@
+package dialects
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
+)
+
+// ErrStaleObject is returned by Update when the version of the model no longer matches the version of the row.
+// The row was either updated by another process since the model was read, or the row no longer exists.
+var ErrStaleObject = errors.New("stale object: the row was modified or deleted since it was read")
+
+// Returns the version attribute and column of the model, if the model declares one
+func versionField(model any) (reflect.Value, string, bool) {
+	if sqlbuilder.IsPointer(model) {
+		return reflect.Value{}, "", false
+	}
+
+	value := reflect.ValueOf(model).Elem()
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, "", false
+	}
+
+	field, column, found := sqlbuilder.VersionField(value.Type())
+	if !found {
+		return reflect.Value{}, "", false
+	}
+
+	return value.FieldByIndex(field.Index), column, true
+}
+
+// Versions start at 1 when the model is created
+func initVersion(model any) {
+	if version, _, found := versionField(model); found && version.Int() == 0 {
+		version.SetInt(1)
+	}
+}
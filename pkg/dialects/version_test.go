@@ -0,0 +1,123 @@
+package dialects
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type document struct {
+	ID      uuid.UUID
+	Title   string
+	Version int `tinyorm:"version"`
+}
+
+func TestOptimisticLocking(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&document{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	doc := &document{ID: uuid.New(), Title: "Draft"}
+	if _, err := db.Create(doc); err != nil {
+		t.Fatalf("error creating document. error: %v", err.Error())
+	}
+
+	if doc.Version != 1 {
+		t.Fatalf("Wanted: 1 - Have: %d", doc.Version)
+	}
+
+	first, second := &document{}, &document{}
+	for _, d := range []*document{first, second} {
+		if err := db.Find(d, doc.ID); err != nil {
+			t.Fatalf("error finding document. error: %v", err.Error())
+		}
+	}
+
+	first.Title = "First"
+	if _, err := db.Update(first); err != nil {
+		t.Fatalf("error updating document. error: %v", err.Error())
+	}
+
+	if first.Version != 2 {
+		t.Fatalf("Wanted: 2 - Have: %d", first.Version)
+	}
+
+	second.Title = "Second"
+	if _, err := db.Update(second); !errors.Is(err, ErrStaleObject) {
+		t.Fatalf("Wanted: %v - Have: %v", ErrStaleObject, err)
+	}
+
+	if second.Version != 1 {
+		t.Fatalf("Wanted: the version to be restored to 1 - Have: %d", second.Version)
+	}
+
+	found := &document{}
+	if err := db.Find(found, doc.ID); err != nil {
+		t.Fatalf("error finding document. error: %v", err.Error())
+	}
+
+	if found.Title != "First" || found.Version != 2 {
+		t.Fatalf("Wanted: First at version 2 - Have: %s at version %d", found.Title, found.Version)
+	}
+
+	if _, err := db.Delete(found); err != nil {
+		t.Fatalf("error deleting document. error: %v", err.Error())
+	}
+
+	if _, err := db.Update(found); !errors.Is(err, ErrStaleObject) {
+		t.Fatalf("Wanted: %v - Have: %v", ErrStaleObject, err)
+	}
+}
+
+var errAfterUpdate = errors.New("after update failed")
+
+type hookDocument struct {
+	ID      uuid.UUID
+	Title   string
+	Version int `tinyorm:"version"`
+}
+
+func (d *hookDocument) AfterUpdate(ctx context.Context) error {
+	return errAfterUpdate
+}
+
+func TestVersionKeptWhenAfterUpdateFails(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&hookDocument{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	doc := &hookDocument{ID: uuid.New(), Title: "Draft"}
+	if _, err := db.Create(doc); err != nil {
+		t.Fatalf("error creating document. error: %v", err.Error())
+	}
+
+	// The row is written before the hook fails, so the model keeps the new version
+	doc.Title = "First"
+	if _, err := db.Update(doc); !errors.Is(err, errAfterUpdate) {
+		t.Fatalf("Wanted: %v - Have: %v", errAfterUpdate, err)
+	}
+
+	if doc.Version != 2 {
+		t.Fatalf("Wanted: 2 - Have: %d", doc.Version)
+	}
+
+	doc.Title = "Second"
+	if _, err := db.Update(doc); !errors.Is(err, errAfterUpdate) {
+		t.Fatalf("Wanted: %v - Have: %v", errAfterUpdate, err)
+	}
+
+	found := &hookDocument{}
+	if err := db.Find(found, doc.ID); err != nil {
+		t.Fatalf("error finding document. error: %v", err.Error())
+	}
+
+	if found.Title != "Second" || found.Version != 3 {
+		t.Fatalf("Wanted: Second at version 3 - Have: %s at version %d", found.Title, found.Version)
+	}
+}
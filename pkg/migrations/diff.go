@@ -0,0 +1,287 @@
+package migrations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/dialects"
+	"github.com/BitlyTwiser/tinyORM/pkg/sqlbuilder"
+)
+
+// Removes the display width from MySQL integer types, i.e. bigint(20)
+var integerWidthRegex = regexp.MustCompile(`^((?:tiny|small|medium|big)?int)\(\d+\)`)
+
+// TypeMismatch is a column whose type within the database differs from the type derived from the model
+type TypeMismatch struct {
+	Column string
+	Want   string
+	Have   string
+}
+
+// TableDiff holds the differences between a single model and its table
+type TableDiff struct {
+	Table          string
+	MissingTable   bool
+	MissingColumns []sqlbuilder.Column
+	ExtraColumns   []sqlbuilder.Column
+	TypeMismatches []TypeMismatch
+	createQuery    string
+}
+
+// Diff holds the differences between the models and the live database schema
+type Diff struct {
+	Tables  []TableDiff
+	dialect string
+}
+
+// SchemaDiff introspects the database and compares each table with the columns derived from the given models.
+// Missing tables, missing or extra columns and column type mismatches are reported.
+// The join tables of many to many relations, as created by AutoMigrate, are reported when missing.
+func SchemaDiff(db dialects.DialectHandler, models ...any) (*Diff, error) {
+	diff := &Diff{dialect: db.Dialect()}
+	tables := make(map[string]struct{})
+	var joins []TableDiff
+
+	for _, model := range models {
+		modelType := sqlbuilder.ModelType(model)
+		td := TableDiff{Table: sqlbuilder.TableName(modelType)}
+		tables[td.Table] = struct{}{}
+
+		missing, err := missingJoinTables(db, modelType, diff.dialect)
+		if err != nil {
+			return nil, err
+		}
+		joins = append(joins, missing...)
+
+		existing, err := db.TableColumns(td.Table)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(existing) == 0 {
+			td.MissingTable = true
+			td.createQuery = sqlbuilder.CreateTableQuery(modelType, diff.dialect)
+			diff.Tables = append(diff.Tables, td)
+
+			continue
+		}
+
+		known := make(map[string]struct{})
+		for _, c := range sqlbuilder.Columns(modelType, diff.dialect) {
+			known[c.Name] = struct{}{}
+
+			have, found := existing[c.Name]
+			if !found {
+				td.MissingColumns = append(td.MissingColumns, c)
+
+				continue
+			}
+
+			if normalizeType(diff.dialect, have) != normalizeType(diff.dialect, c.Type) {
+				td.TypeMismatches = append(td.TypeMismatches, TypeMismatch{Column: c.Name, Want: c.Type, Have: have})
+			}
+		}
+
+		for name, columnType := range existing {
+			if _, found := known[name]; !found {
+				td.ExtraColumns = append(td.ExtraColumns, sqlbuilder.Column{Name: name, Type: columnType})
+			}
+		}
+		sort.Slice(td.ExtraColumns, func(i, j int) bool { return td.ExtraColumns[i].Name < td.ExtraColumns[j].Name })
+
+		if !td.Empty() {
+			diff.Tables = append(diff.Tables, td)
+		}
+	}
+
+	// Both models of a relation share the join table, which may also be given as a model
+	for _, td := range joins {
+		if _, found := tables[td.Table]; !found {
+			tables[td.Table] = struct{}{}
+			diff.Tables = append(diff.Tables, td)
+		}
+	}
+
+	return diff, nil
+}
+
+// Returns the join tables of the many to many relations of the model which do not exist
+func missingJoinTables(db dialects.DialectHandler, modelType reflect.Type, dialect string) ([]TableDiff, error) {
+	var missing []TableDiff
+
+	relations, err := sqlbuilder.Relations(modelType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range relations {
+		if r.Kind != sqlbuilder.MANY_TO_MANY {
+			continue
+		}
+
+		existing, err := db.TableColumns(r.JoinTable)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(existing) == 0 {
+			missing = append(missing, TableDiff{Table: r.JoinTable, MissingTable: true, createQuery: sqlbuilder.JoinTableQuery(modelType, r, dialect)})
+		}
+	}
+
+	return missing, nil
+}
+
+// Empty denotes that the table matches the model
+func (td TableDiff) Empty() bool {
+	return !td.MissingTable && len(td.MissingColumns) == 0 && len(td.ExtraColumns) == 0 && len(td.TypeMismatches) == 0
+}
+
+// Empty denotes that the database schema matches all of the models
+func (d *Diff) Empty() bool {
+	return len(d.Tables) == 0
+}
+
+// String reports the differences in a human readable format
+func (d *Diff) String() string {
+	var s strings.Builder
+
+	for _, td := range d.Tables {
+		if td.MissingTable {
+			s.WriteString(fmt.Sprintf("table %s is missing\n", td.Table))
+
+			continue
+		}
+
+		for _, c := range td.MissingColumns {
+			s.WriteString(fmt.Sprintf("table %s is missing column %s %s\n", td.Table, c.Name, c.Type))
+		}
+
+		for _, c := range td.ExtraColumns {
+			s.WriteString(fmt.Sprintf("table %s has extra column %s %s\n", td.Table, c.Name, c.Type))
+		}
+
+		for _, m := range td.TypeMismatches {
+			s.WriteString(fmt.Sprintf("table %s column %s has type %s, model expects %s\n", td.Table, m.Column, m.Have, m.Want))
+		}
+	}
+
+	return s.String()
+}
+
+// Migration proposes the up and down SQL resolving the differences.
+// Extra columns are never dropped automatically, the statements are written as comments for review.
+func (d *Diff) Migration() (up string, down string) {
+	var u, dn strings.Builder
+
+	for _, td := range d.Tables {
+		if td.MissingTable {
+			u.WriteString(td.createQuery + ";\n")
+			dn.WriteString(fmt.Sprintf("DROP TABLE IF EXISTS %s;\n", td.Table))
+
+			continue
+		}
+
+		for _, c := range td.MissingColumns {
+			u.WriteString(sqlbuilder.AddColumnQuery(td.Table, c) + ";\n")
+			dn.WriteString(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;\n", td.Table, c.Name))
+		}
+
+		for _, c := range td.ExtraColumns {
+			u.WriteString(fmt.Sprintf("-- ALTER TABLE %s DROP COLUMN %s;\n", td.Table, c.Name))
+		}
+
+		for _, m := range td.TypeMismatches {
+			u.WriteString(d.alterColumnType(td.Table, m.Column, m.Want) + "\n")
+			dn.WriteString(d.alterColumnType(td.Table, m.Column, m.Have) + "\n")
+		}
+	}
+
+	return u.String(), dn.String()
+}
+
+// WriteMigration writes the proposed migration into the given directory using the next available version.
+// The path of the up migration file is returned.
+func (d *Diff) WriteMigration(dir string, name string) (string, error) {
+	var version int64
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	for _, e := range entries {
+		if matches := fileNameRegex.FindStringSubmatch(e.Name()); matches != nil {
+			if v, err := strconv.ParseInt(matches[1], 10, 64); err == nil && v > version {
+				version = v
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	up, down := d.Migration()
+	base := filepath.Join(dir, fmt.Sprintf("%04d_%s", version+1, name))
+
+	if err := os.WriteFile(base+".up.sql", []byte(up), 0o644); err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(base+".down.sql", []byte(down), 0o644); err != nil {
+		return "", err
+	}
+
+	return base + ".up.sql", nil
+}
+
+// SQLite cannot alter the type of an existing column, the statement is written as a comment for review.
+func (d *Diff) alterColumnType(table string, column string, columnType string) string {
+	switch d.dialect {
+	case dialects.DIALECT_TYPE_PSQL:
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", table, column, columnType)
+	case dialects.DIALECT_TYPE_MYSQL:
+		return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s;", table, column, columnType)
+	}
+
+	return fmt.Sprintf("-- SQLite cannot alter column types, recreate table %s with column %s %s", table, column, columnType)
+}
+
+// Normalizes the column type so the types reported by the database can be compared to the types derived from the models
+func normalizeType(dialect string, columnType string) string {
+	t := strings.ToLower(strings.TrimSpace(columnType))
+
+	switch dialect {
+	case dialects.DIALECT_TYPE_PSQL:
+		switch t {
+		case "bigserial":
+			return "bigint"
+		case "serial":
+			return "integer"
+		case "timestamp without time zone":
+			return "timestamp"
+		case "character varying":
+			return "varchar"
+		}
+	case dialects.DIALECT_TYPE_MYSQL:
+		t = strings.TrimSuffix(t, " auto_increment")
+		switch t {
+		case "boolean", "bool":
+			return "tinyint(1)"
+		}
+
+		// tinyint(1) is the boolean type, the width is significant
+		if t != "tinyint(1)" {
+			t = integerWidthRegex.ReplaceAllString(t, "$1")
+		}
+	}
+
+	return t
+}
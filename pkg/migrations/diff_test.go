@@ -0,0 +1,146 @@
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type user struct {
+	ID    uuid.UUID
+	Name  string
+	Age   int
+	Email string
+}
+
+type vehicle struct {
+	ID    uuid.UUID
+	Color string
+}
+
+func TestSchemaDiff(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.Exec("CREATE TABLE users (id TEXT PRIMARY KEY, name TEXT, nickname TEXT, age TEXT)"); err != nil {
+		t.Fatalf("error creating users table. error: %v", err.Error())
+	}
+
+	diff, err := SchemaDiff(db, &user{}, &vehicle{})
+	if err != nil {
+		t.Fatalf("error diffing schema. error: %v", err.Error())
+	}
+
+	if len(diff.Tables) != 2 {
+		t.Fatalf("Wanted: 2 table diffs - Have: %d. %s", len(diff.Tables), diff)
+	}
+
+	users := diff.Tables[0]
+	if len(users.MissingColumns) != 1 || users.MissingColumns[0].Name != "email" {
+		t.Fatalf("Wanted: missing email column - Have: %v", users.MissingColumns)
+	}
+
+	if len(users.ExtraColumns) != 1 || users.ExtraColumns[0].Name != "nickname" {
+		t.Fatalf("Wanted: extra nickname column - Have: %v", users.ExtraColumns)
+	}
+
+	if len(users.TypeMismatches) != 1 || users.TypeMismatches[0].Column != "age" {
+		t.Fatalf("Wanted: age type mismatch - Have: %v", users.TypeMismatches)
+	}
+
+	if !diff.Tables[1].MissingTable {
+		t.Fatalf("Wanted: vehicles table missing - Have: %v", diff.Tables[1])
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "0003_existing.up.sql"), []byte("SELECT 1;"), 0o644); err != nil {
+		t.Fatalf("error writing migration. error: %v", err.Error())
+	}
+
+	path, err := diff.WriteMigration(dir, "sync_models")
+	if err != nil {
+		t.Fatalf("error writing migration. error: %v", err.Error())
+	}
+
+	if filepath.Base(path) != "0004_sync_models.up.sql" {
+		t.Fatalf("Wanted: 0004_sync_models.up.sql - Have: %s", filepath.Base(path))
+	}
+
+	up, _ := os.ReadFile(path)
+	if err := db.Exec(string(up)); err != nil {
+		t.Fatalf("error applying proposed migration. error: %v", err.Error())
+	}
+
+	diff, err = SchemaDiff(db, &user{}, &vehicle{})
+	if err != nil {
+		t.Fatalf("error diffing schema. error: %v", err.Error())
+	}
+
+	// SQLite cannot alter the column type and extra columns are never dropped automatically
+	if len(diff.Tables) != 1 || len(diff.Tables[0].MissingColumns) != 0 || !strings.Contains(diff.String(), "nickname") {
+		t.Fatalf("unexpected diff after applying migration. %s", diff)
+	}
+}
+
+func TestSchemaDiffEmpty(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	if err := db.AutoMigrate(&user{}, &vehicle{}, &student{}, &course{}); err != nil {
+		t.Fatalf("error migrating models. error: %v", err.Error())
+	}
+
+	diff, err := SchemaDiff(db, &user{}, &vehicle{}, &student{}, &course{})
+	if err != nil {
+		t.Fatalf("error diffing schema. error: %v", err.Error())
+	}
+
+	if !diff.Empty() {
+		t.Fatalf("Wanted: empty diff - Have: %s", diff)
+	}
+}
+
+type student struct {
+	ID      uuid.UUID
+	Name    string
+	Courses []course `tinyorm:"many2many"`
+}
+
+type course struct {
+	ID       uuid.UUID
+	Title    string
+	Students []student `tinyorm:"many2many=student_courses,join_foreign_key=course_id,join_references=student_id"`
+}
+
+func TestSchemaDiffJoinTables(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	diff, err := SchemaDiff(db, &student{}, &course{})
+	if err != nil {
+		t.Fatalf("error diffing schema. error: %v", err.Error())
+	}
+
+	// The join table is shared by both models and reported once
+	if len(diff.Tables) != 3 || diff.Tables[2].Table != "student_courses" || !diff.Tables[2].MissingTable {
+		t.Fatalf("Wanted: missing student_courses join table - Have: %s", diff)
+	}
+
+	up, down := diff.Migration()
+	if err := db.Exec(up); err != nil {
+		t.Fatalf("error applying proposed migration. error: %v", err.Error())
+	}
+
+	if !strings.Contains(down, "DROP TABLE IF EXISTS student_courses;") {
+		t.Fatalf("Wanted: join table dropped - Have: %s", down)
+	}
+
+	diff, err = SchemaDiff(db, &student{}, &course{})
+	if err != nil {
+		t.Fatalf("error diffing schema. error: %v", err.Error())
+	}
+
+	if !diff.Empty() {
+		t.Fatalf("Wanted: empty diff - Have: %s", diff)
+	}
+}
@@ -0,0 +1,280 @@
+// Migrations applies versioned up/down SQL files to a database, tracking the applied versions within the schema_migrations table
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/dialects"
+	"github.com/BitlyTwiser/tinyORM/pkg/logger"
+)
+
+const createSchemaMigrations = "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, name VARCHAR(255) NOT NULL)"
+
+// Migration files must be named NNNN_name.up.sql or NNNN_name.down.sql
+var fileNameRegex = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is a single versioned migration parsed from the up/down SQL files
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status denotes if the given migration has been applied to the database
+type Status struct {
+	Version int64
+	Name    string
+	Applied bool
+}
+
+// Row stored within the schema_migrations table
+type schemaMigration struct {
+	Version int64
+	Name    string
+}
+
+type schemaMigrations []schemaMigration
+
+type Migrator struct {
+	db         dialects.DialectHandler
+	migrations []Migration
+}
+
+// New parses all migration files within the root of the given file system. An embed.FS can be passed directly,
+// use fs.Sub if the migrations are within a sub directory of the embedded files.
+func New(db dialects.DialectHandler, fsys fs.FS) (*Migrator, error) {
+	migrations, err := parseMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// NewFromDir parses all migration files within the given directory
+func NewFromDir(db dialects.DialectHandler, dir string) (*Migrator, error) {
+	return New(db, os.DirFS(dir))
+}
+
+// Up applies all migrations that have not yet been applied, in order of their version
+func (m *Migrator) Up() error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		if _, found := applied[migration.Version]; found {
+			continue
+		}
+
+		if err := m.up(migration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Down rolls back the last n applied migrations, newest first
+func (m *Migrator) Down(n int) error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0 && n > 0; i-- {
+		migration := m.migrations[i]
+		if _, found := applied[migration.Version]; !found {
+			continue
+		}
+
+		if err := m.down(migration); err != nil {
+			return err
+		}
+		n--
+	}
+
+	return nil
+}
+
+// Redo rolls back the last applied migration and applies it again
+func (m *Migrator) Redo() error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, found := applied[migration.Version]; !found {
+			continue
+		}
+
+		if err := m.down(migration); err != nil {
+			return err
+		}
+
+		return m.up(migration)
+	}
+
+	return errors.New("no applied migrations were found to redo")
+}
+
+// Status returns all known migrations and if each has been applied to the database
+func (m *Migrator) Status() ([]Status, error) {
+	var statuses []Status
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, migration := range m.migrations {
+		_, found := applied[migration.Version]
+		statuses = append(statuses, Status{Version: migration.Version, Name: migration.Name, Applied: found})
+	}
+
+	return statuses, nil
+}
+
+// Migrations returns all parsed migrations ordered by version
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+func (m *Migrator) up(migration Migration) error {
+	logger.Log.LogEvent("info", "applying migration", "version", migration.Version, "name", migration.Name)
+
+	err := m.run(func(db dialects.DialectHandler) error {
+		if err := db.Exec(migration.Up); err != nil {
+			return err
+		}
+
+		_, err := db.Create(&schemaMigration{Version: migration.Version, Name: migration.Name})
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error applying migration %d_%s. error: %w", migration.Version, migration.Name, err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) down(migration Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("no down migration was found for migration %d_%s", migration.Version, migration.Name)
+	}
+
+	logger.Log.LogEvent("info", "rolling back migration", "version", migration.Version, "name", migration.Name)
+
+	err := m.run(func(db dialects.DialectHandler) error {
+		if err := db.Exec(migration.Down); err != nil {
+			return err
+		}
+
+		_, err := db.Delete(&schemaMigration{Version: migration.Version})
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error rolling back migration %d_%s. error: %w", migration.Version, migration.Name, err)
+	}
+
+	return nil
+}
+
+// Runs the migration within a transaction. MySQL implicitly commits DDL statements, so no transaction is used.
+func (m *Migrator) run(fn func(db dialects.DialectHandler) error) error {
+	if m.db.Dialect() == dialects.DIALECT_TYPE_MYSQL {
+		return fn(m.db)
+	}
+
+	return m.db.Transaction(fn)
+}
+
+// Returns the set of applied versions, creating the schema_migrations table if it does not exist
+func (m *Migrator) applied() (map[int64]struct{}, error) {
+	if err := m.db.Exec(createSchemaMigrations); err != nil {
+		return nil, fmt.Errorf("error creating schema_migrations table. error: %w", err)
+	}
+
+	rows := new(schemaMigrations)
+	if err := m.db.Model(rows).All(); err != nil {
+		return nil, fmt.Errorf("error reading schema_migrations table. error: %w", err)
+	}
+
+	applied := make(map[int64]struct{}, len(*rows))
+	for _, r := range *rows {
+		applied[r.Version] = struct{}{}
+	}
+
+	return applied, nil
+}
+
+func parseMigrations(fsys fs.FS) ([]Migration, error) {
+	byVersion := make(map[int64]*Migration)
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations directory. error: %w", err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		matches := fileNameRegex.FindStringSubmatch(e.Name())
+		if matches == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version for file %s. Versions must be greater than 0", e.Name())
+		}
+
+		data, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration file %s. error: %w", e.Name(), err)
+		}
+
+		migration, found := byVersion[version]
+		if !found {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != matches[2] {
+			return nil, fmt.Errorf("duplicate migration version %d found for %s and %s", version, migration.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("no up migration was found for migration %d_%s", migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
@@ -0,0 +1,182 @@
+package migrations
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/dialects"
+)
+
+var testMigrations = fstest.MapFS{
+	"0001_create_users.up.sql":      {Data: []byte("CREATE TABLE users (id TEXT PRIMARY KEY, name TEXT);")},
+	"0001_create_users.down.sql":    {Data: []byte("DROP TABLE users;")},
+	"0002_create_vehicles.up.sql":   {Data: []byte("CREATE TABLE vehicles (id TEXT PRIMARY KEY, color TEXT); CREATE INDEX vehicles_color ON vehicles (color);")},
+	"0002_create_vehicles.down.sql": {Data: []byte("DROP TABLE vehicles;")},
+	"README.md":                     {Data: []byte("not a migration")},
+}
+
+func newSQLiteHandler(t *testing.T) dialects.DialectHandler {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "tinyorm.db"))
+	if err != nil {
+		t.Fatalf("error opening sqlite database. error: %v", err.Error())
+	}
+	t.Cleanup(func() { db.Close() })
+
+	handler := &dialects.SQLite{}
+	handler.SetDB(db)
+
+	return handler
+}
+
+func appliedVersions(t *testing.T, m *Migrator) []int64 {
+	t.Helper()
+
+	var versions []int64
+
+	statuses, err := m.Status()
+	if err != nil {
+		t.Fatalf("error reading migration status. error: %v", err.Error())
+	}
+
+	for _, s := range statuses {
+		if s.Applied {
+			versions = append(versions, s.Version)
+		}
+	}
+
+	return versions
+}
+
+func TestMigrator(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	m, err := New(db, testMigrations)
+	if err != nil {
+		t.Fatalf("error parsing migrations. error: %v", err.Error())
+	}
+
+	if len(m.Migrations()) != 2 {
+		t.Fatalf("Wanted: 2 migrations - Have: %d", len(m.Migrations()))
+	}
+
+	if err := m.Up(); err != nil {
+		t.Fatalf("error applying migrations. error: %v", err.Error())
+	}
+
+	if versions := appliedVersions(t, m); len(versions) != 2 {
+		t.Fatalf("Wanted: 2 applied migrations - Have: %v", versions)
+	}
+
+	// Running up again should be a no-op
+	if err := m.Up(); err != nil {
+		t.Fatalf("error re-applying migrations. error: %v", err.Error())
+	}
+
+	if err := m.Redo(); err != nil {
+		t.Fatalf("error redoing migration. error: %v", err.Error())
+	}
+
+	if err := m.Down(1); err != nil {
+		t.Fatalf("error rolling back migration. error: %v", err.Error())
+	}
+
+	if versions := appliedVersions(t, m); len(versions) != 1 || versions[0] != 1 {
+		t.Fatalf("Wanted: [1] - Have: %v", versions)
+	}
+
+	if err := db.Exec("SELECT * FROM vehicles"); err == nil {
+		t.Fatalf("expected vehicles table to be dropped")
+	}
+
+	if err := m.Down(5); err != nil {
+		t.Fatalf("error rolling back migrations. error: %v", err.Error())
+	}
+
+	if versions := appliedVersions(t, m); len(versions) != 0 {
+		t.Fatalf("Wanted: no applied migrations - Have: %v", versions)
+	}
+}
+
+type seed struct {
+	ID   string
+	Name string
+}
+
+func TestMultiStatementMigration(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	m, err := New(db, fstest.MapFS{
+		"0001_seed_users.up.sql": {Data: []byte(`CREATE TABLE seeds (id TEXT PRIMARY KEY, name TEXT);
+-- Seed the rows; the semicolon within the comment and string is not a statement
+INSERT INTO seeds (id, name) VALUES ('1', 'carl; the first');
+INSERT INTO seeds (id, name) VALUES ('2', 'jane');`)},
+		"0001_seed_users.down.sql": {Data: []byte("DELETE FROM seeds; DROP TABLE seeds;")},
+	})
+	if err != nil {
+		t.Fatalf("error parsing migrations. error: %v", err.Error())
+	}
+
+	if err := m.Up(); err != nil {
+		t.Fatalf("error applying migrations. error: %v", err.Error())
+	}
+
+	seeded := []seed{}
+	if err := db.Find(&seeded); err != nil {
+		t.Fatalf("error finding seeded rows. error: %v", err.Error())
+	}
+
+	if len(seeded) != 2 {
+		t.Fatalf("Wanted: 2 seeded rows - Have: %d", len(seeded))
+	}
+
+	if err := m.Down(1); err != nil {
+		t.Fatalf("error rolling back migrations. error: %v", err.Error())
+	}
+
+	if err := db.Exec("SELECT * FROM seeds"); err == nil {
+		t.Fatalf("expected seeds table to be dropped")
+	}
+}
+
+func TestFailedMigrationIsRolledBack(t *testing.T) {
+	db := newSQLiteHandler(t)
+
+	m, err := New(db, fstest.MapFS{
+		"0001_broken.up.sql": {Data: []byte("CREATE TABLE users (id TEXT); INSERT INTO missing_table VALUES (1);")},
+	})
+	if err != nil {
+		t.Fatalf("error parsing migrations. error: %v", err.Error())
+	}
+
+	if err := m.Up(); err == nil {
+		t.Fatalf("expected broken migration to fail")
+	}
+
+	if versions := appliedVersions(t, m); len(versions) != 0 {
+		t.Fatalf("Wanted: no applied migrations - Have: %v", versions)
+	}
+
+	if err := db.Exec("SELECT * FROM users"); err == nil {
+		t.Fatalf("expected users table creation to be rolled back")
+	}
+}
+
+func TestParseMigrationsErrors(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"Test missing up migration": {"0001_users.down.sql": {Data: []byte("DROP TABLE users;")}},
+		"Test duplicate versions":   {"0001_users.up.sql": {Data: []byte("SELECT 1;")}, "0001_vehicles.up.sql": {Data: []byte("SELECT 1;")}},
+		"Test zero version":         {"0000_users.up.sql": {Data: []byte("SELECT 1;")}},
+	}
+
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseMigrations(fsys); err == nil {
+				t.Fatalf("expected error parsing migrations")
+			}
+		})
+	}
+}
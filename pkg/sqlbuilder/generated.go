@@ -0,0 +1,61 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"reflect"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/logger"
+)
+
+// TinyModel is implemented by models with methods generated by tinyorm gen.
+// Rows are scanned into and values are bound from the generated methods instead of reflecting over the model attributes.
+type TinyModel interface {
+	// Columns of the model in the order of the model attributes
+	TinyColumns() []string
+	// Pointers scanned into for each column
+	TinyScanDest() []any
+	// Values of each column
+	TinyValues() []any
+}
+
+var tinyModelType = reflect.TypeOf((*TinyModel)(nil)).Elem()
+
+// ScanTime wraps the pointer to a time attribute so NULL and textual timestamps can be scanned. Used by generated TinyScanDest methods.
+func ScanTime(dest any) sql.Scanner {
+	return &timeScanner{dest: reflect.ValueOf(dest).Elem()}
+}
+
+// ColumnOf returns the column name of the struct field, false if the field is not a table column. Used by tinyorm gen.
+func ColumnOf(field reflect.StructField) (string, bool) {
+	if !isColumn(field) {
+		return "", false
+	}
+
+	return columnName(field), true
+}
+
+// Determines if the generated methods of the model can be used.
+// Methods generated before the model attributes were changed are ignored so rows are never scanned into the wrong attributes.
+func generatedColumns(model reflect.Type, columns []string) bool {
+	if model.Kind() != reflect.Struct || !reflect.PointerTo(model).Implements(tinyModelType) {
+		return false
+	}
+
+	generated := reflect.New(model).Interface().(TinyModel).TinyColumns()
+	if !reflect.DeepEqual(generated, columns) {
+		logger.Log.LogEvent("warn", "generated columns do not match the model attributes, re-run tinyorm gen. Falling back to reflection", "model", model.Name())
+
+		return false
+	}
+
+	return true
+}
+
+// Returns the generated methods of the model value, false if the model has none or the value is not addressable
+func (m *ModelInfo) generated(model reflect.Value) (TinyModel, bool) {
+	if !m.Generated || !model.CanAddr() {
+		return nil, false
+	}
+
+	return model.Addr().Interface().(TinyModel), true
+}
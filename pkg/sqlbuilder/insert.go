@@ -0,0 +1,98 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// SetMissingID generates a uuid for the id attribute of the model if it is empty.
+// Only uuid.UUID and string ids are generated, integer ids are left to the database to increment.
+func SetMissingID(model reflect.Value) {
+	model = reflect.Indirect(model)
+
+	f, found := FieldByColumn(model.Type(), "id")
+	if !found {
+		return
+	}
+
+	id := model.FieldByIndex(f.Index)
+	if !id.IsZero() {
+		return
+	}
+
+	switch {
+	case f.Type == uuidType:
+		id.Set(reflect.ValueOf(uuid.New()))
+	case f.Type.Kind() == reflect.String:
+		id.SetString(uuid.New().String())
+	}
+}
+
+// InsertGroup holds the models sharing the same non zero columns, inserted together using multi row INSERT statements
+type InsertGroup struct {
+	Columns []string
+	Models  []reflect.Value
+}
+
+// InsertGroups groups the models by the columns holding a non zero value, in the order the models are first seen.
+// Empty columns are left out so the database default applies, the same as Create.
+func InsertGroups(models []reflect.Value) []InsertGroup {
+	var groups []InsertGroup
+	found := make(map[string]int)
+
+	for _, m := range models {
+		columns := InsertColumns(m)
+		key := strings.Join(columns, ",")
+
+		i, ok := found[key]
+		if !ok {
+			i = len(groups)
+			found[key] = i
+			groups = append(groups, InsertGroup{Columns: columns})
+		}
+
+		groups[i].Models = append(groups[i].Models, m)
+	}
+
+	return groups
+}
+
+// InsertColumns returns the columns of the model holding a non zero value, in the order of the model attributes
+func InsertColumns(model reflect.Value) []string {
+	var columns []string
+
+	model = reflect.Indirect(model)
+	for _, f := range ModelInfoOf(model.Type()).Fields {
+		if !model.Field(f.Index).IsZero() {
+			columns = append(columns, f.Column)
+		}
+	}
+
+	return columns
+}
+
+// InsertValues returns the values of the model for the given columns
+func InsertValues(model reflect.Value, columns []string) []any {
+	var values []any
+
+	model = reflect.Indirect(model)
+	info := ModelInfoOf(model.Type())
+	modelValues := info.Values(model)
+
+	for _, c := range columns {
+		values = append(values, modelValues[info.byColumn[c]])
+	}
+
+	return values
+}
+
+// InsertQuery builds a multi row INSERT query for the given amount of rows, i.e. INSERT INTO users (id, name) VALUES ($1, $2), ($3, $4)
+func InsertQuery(tableName string, columns []string, rows int, databaseType string) string {
+	row := "(" + Placeholders(len(columns)) + ")"
+	values := strings.TrimSuffix(strings.Repeat(row+", ", rows), ", ")
+
+	return Rebind(fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", tableName, strings.Join(columns, ", "), values), databaseType, 1)
+}
@@ -0,0 +1,133 @@
+package sqlbuilder
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Registry of the ModelInfo of every model type seen, keyed by the reflect.Type of the struct
+var models sync.Map
+
+// ModelInfo holds the reflected metadata of a model struct.
+// It is computed once per type by ModelInfoOf and must not be modified.
+type ModelInfo struct {
+	Type       reflect.Type
+	TableName  string
+	Columns    []string    // Column names in the order of the model attributes
+	Fields     []FieldInfo // Column attributes in the order of the model attributes
+	PrimaryKey int         // Position of the id column within Fields, -1 when the model has no id
+	SelectList string      // Columns wrapped in COALESCE as selected by Find and Where
+	Generated  bool        // The model implements TinyModel with columns matching the model attributes
+
+	byColumn map[string]int
+}
+
+// FieldInfo is an attribute of the model mapped to a table column
+type FieldInfo struct {
+	StructField reflect.StructField
+	Column      string
+	Index       int // Index of the attribute within the struct
+	Type        reflect.Type
+	Options     map[string]string // Parsed options of the tinyorm tag
+	Time        bool              // Scanned using the timeScanner
+}
+
+// ModelInfoOf returns the metadata of the model struct type, building and caching it on first use
+func ModelInfoOf(model reflect.Type) *ModelInfo {
+	if info, found := models.Load(model); found {
+		return info.(*ModelInfo)
+	}
+
+	// Concurrent first calls may both build the metadata, only the first stored is kept
+	info, _ := models.LoadOrStore(model, newModelInfo(model))
+
+	return info.(*ModelInfo)
+}
+
+func newModelInfo(model reflect.Type) *ModelInfo {
+	info := &ModelInfo{
+		Type:       model,
+		TableName:  tableName(model),
+		PrimaryKey: -1,
+		byColumn:   make(map[string]int),
+	}
+
+	if model.Kind() != reflect.Struct {
+		return info
+	}
+
+	var selectList []string
+	for i := 0; i < model.NumField(); i++ {
+		f := model.Field(i)
+		if !isColumn(f) {
+			continue
+		}
+
+		name := columnName(f)
+		if name == "id" && info.PrimaryKey == -1 {
+			info.PrimaryKey = len(info.Fields)
+		}
+
+		// The first attribute mapped to a column wins, the same as walking the fields in order
+		if _, found := info.byColumn[name]; !found {
+			info.byColumn[name] = len(info.Fields)
+		}
+
+		info.Columns = append(info.Columns, name)
+		info.Fields = append(info.Fields, FieldInfo{StructField: f, Column: name, Index: i, Type: f.Type, Options: parseTag(f), Time: IsTime(f.Type)})
+		selectList = append(selectList, coalesceColumn(name, f.Type.Kind()))
+	}
+	info.SelectList = strings.Join(selectList, ", ")
+	info.Generated = generatedColumns(model, info.Columns)
+
+	return info
+}
+
+// Field returns the attribute mapped to the column
+func (m *ModelInfo) Field(column string) (FieldInfo, bool) {
+	i, found := m.byColumn[column]
+	if !found {
+		return FieldInfo{}, false
+	}
+
+	return m.Fields[i], true
+}
+
+// Pointers returns the pointers scanned into for every column of the model value
+func (m *ModelInfo) Pointers(model reflect.Value) []any {
+	if g, ok := m.generated(model); ok {
+		return g.TinyScanDest()
+	}
+
+	pointers := make([]any, len(m.Fields))
+
+	for i, f := range m.Fields {
+		pointers[i] = f.pointer(model.Field(f.Index))
+	}
+
+	return pointers
+}
+
+// Values returns the values of every column of the model value
+func (m *ModelInfo) Values(model reflect.Value) []any {
+	if g, ok := m.generated(model); ok {
+		return g.TinyValues()
+	}
+
+	values := make([]any, len(m.Fields))
+
+	for i, f := range m.Fields {
+		values[i] = model.Field(f.Index).Interface()
+	}
+
+	return values
+}
+
+func (f FieldInfo) pointer(field reflect.Value) any {
+	if f.Time {
+		return &timeScanner{dest: field}
+	}
+
+	return field.Addr().Interface()
+}
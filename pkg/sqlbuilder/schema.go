@@ -0,0 +1,163 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Column is a single table column derived from a model attribute
+type Column struct {
+	Name       string
+	Type       string
+	PrimaryKey bool
+}
+
+var (
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	timeType       = reflect.TypeOf(time.Time{})
+	nullStringType = reflect.TypeOf(sql.NullString{})
+	nullInt64Type  = reflect.TypeOf(sql.NullInt64{})
+	nullInt32Type  = reflect.TypeOf(sql.NullInt32{})
+	nullBoolType   = reflect.TypeOf(sql.NullBool{})
+	nullFloatType  = reflect.TypeOf(sql.NullFloat64{})
+	nullTimeType   = reflect.TypeOf(sql.NullTime{})
+)
+
+// ModelType returns the struct type of the given model, removing any pointers and slices.
+// i.e. *[]User, *User and User all return the User type
+func ModelType(model any) reflect.Type {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// Columns derives the table columns and the dialect appropriate column types from the attributes of the model.
+// The id attribute is treated as the primary key.
+func Columns(model reflect.Type, databaseType string) []Column {
+	var columns []Column
+
+	for _, f := range ModelInfoOf(model).Fields {
+		name := f.Column
+
+		if name == "id" {
+			columns = append(columns, Column{Name: name, Type: primaryKeyType(f.Type, databaseType), PrimaryKey: true})
+
+			continue
+		}
+
+		columns = append(columns, Column{Name: name, Type: ColumnType(f.Type, databaseType)})
+	}
+
+	return columns
+}
+
+// ColumnType maps the go type into the column type of the given database type.
+// i.e. uuid.UUID is UUID in postgres, BINARY(36) in MySQL and TEXT in SQLite
+func ColumnType(t reflect.Type, databaseType string) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case uuidType:
+		return dialectType(databaseType, "UUID", "BINARY(36)", "TEXT")
+	case timeType, nullTimeType:
+		return dialectType(databaseType, "TIMESTAMP", "DATETIME", "DATETIME")
+	case nullStringType:
+		return "TEXT"
+	case nullInt64Type:
+		return dialectType(databaseType, "BIGINT", "BIGINT", "INTEGER")
+	case nullInt32Type:
+		return "INTEGER"
+	case nullBoolType:
+		return "BOOLEAN"
+	case nullFloatType:
+		return dialectType(databaseType, "DOUBLE PRECISION", "DOUBLE", "REAL")
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "TEXT"
+	case reflect.Bool:
+		return "BOOLEAN"
+	case reflect.Int8, reflect.Int16, reflect.Uint8, reflect.Uint16:
+		return dialectType(databaseType, "SMALLINT", "SMALLINT", "INTEGER")
+	case reflect.Int32, reflect.Uint32:
+		return "INTEGER"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return dialectType(databaseType, "BIGINT", "BIGINT", "INTEGER")
+	case reflect.Float32:
+		return "REAL"
+	case reflect.Float64:
+		return dialectType(databaseType, "DOUBLE PRECISION", "DOUBLE", "REAL")
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return dialectType(databaseType, "BYTEA", "BLOB", "BLOB")
+		}
+
+		// Slices are stored as json, i.e. custom.Slice
+		return dialectType(databaseType, "JSONB", "JSON", "TEXT")
+	case reflect.Map:
+		// Maps are stored as json, i.e. custom.Map
+		return dialectType(databaseType, "JSONB", "JSON", "TEXT")
+	}
+
+	return "TEXT"
+}
+
+// Integer ids are auto incremented by the database, all other ids are the mapped column type
+func primaryKeyType(t reflect.Type, databaseType string) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return dialectType(databaseType, "BIGSERIAL", "BIGINT AUTO_INCREMENT", "INTEGER")
+	case reflect.String:
+		// MySQL cannot index TEXT columns without a length
+		return dialectType(databaseType, "TEXT", "VARCHAR(255)", "TEXT")
+	}
+
+	return ColumnType(t, databaseType)
+}
+
+func dialectType(databaseType string, psql string, mysql string, sqlite string) string {
+	switch databaseType {
+	case "psql":
+		return psql
+	case "mysql":
+		return mysql
+	}
+
+	return sqlite
+}
+
+// CreateTableQuery builds the CREATE TABLE IF NOT EXISTS query for the given model type
+func CreateTableQuery(model reflect.Type, databaseType string) string {
+	var definitions []string
+
+	for _, c := range Columns(model, databaseType) {
+		definitions = append(definitions, columnDefinition(c))
+	}
+
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", TableName(model), strings.Join(definitions, ", "))
+}
+
+// AddColumnQuery builds the ALTER TABLE query adding the column to the table.
+// Primary key constraints are not added to existing tables.
+func AddColumnQuery(tableName string, column Column) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", tableName, column.Name, column.Type)
+}
+
+func columnDefinition(c Column) string {
+	if c.PrimaryKey {
+		return c.Name + " " + c.Type + " PRIMARY KEY"
+	}
+
+	return c.Name + " " + c.Type
+}
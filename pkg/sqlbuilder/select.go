@@ -0,0 +1,173 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type condition struct {
+	stmt string
+	args []any
+}
+
+// SelectQuery holds the parts of a SELECT statement that are composed by the chainable query builder.
+// The statement is rendered with dialect correct placeholders by calling Build.
+type SelectQuery struct {
+	TableName  string
+	Columns    string
+	conditions []condition
+	orders     []string
+	limit      int
+	offset     int
+}
+
+// Where appends a condition to the query, multiple conditions are joined with AND.
+// Conditions use ? as the placeholder regardless of dialect.
+func (s *SelectQuery) Where(stmt string, args ...any) {
+	s.conditions = append(s.conditions, condition{stmt: stmt, args: args})
+}
+
+// Order appends an ordering clause, i.e. "created_at desc"
+func (s *SelectQuery) Order(order string) {
+	s.orders = append(s.orders, order)
+}
+
+// Limit sets the maximum rows returned. A limit of <= 0 will return all rows
+func (s *SelectQuery) Limit(limit int) {
+	s.limit = limit
+}
+
+// Offset sets the number of rows skipped. An offset of <= 0 skips no rows
+func (s *SelectQuery) Offset(offset int) {
+	s.offset = offset
+}
+
+// Build renders the SELECT statement for the given database type along with the arguments for the statement
+func (s *SelectQuery) Build(databaseType string) (string, []any) {
+	return s.build(databaseType, s.Columns, true)
+}
+
+// BuildCount renders a SELECT COUNT(*) statement using the conditions of the query
+func (s *SelectQuery) BuildCount(databaseType string) (string, []any) {
+	return s.build(databaseType, "COUNT(*)", false)
+}
+
+func (s *SelectQuery) build(databaseType string, columns string, paginate bool) (string, []any) {
+	var query strings.Builder
+	var args []any
+
+	query.WriteString(fmt.Sprintf("SELECT %s FROM %s", columns, s.TableName))
+
+	for i, c := range s.conditions {
+		if i == 0 {
+			query.WriteString(" WHERE ")
+		} else {
+			query.WriteString(" AND ")
+		}
+
+		query.WriteString("(" + Rebind(c.stmt, databaseType, len(args)+1) + ")")
+		args = append(args, c.args...)
+	}
+
+	if !paginate {
+		return query.String(), args
+	}
+
+	if len(s.orders) > 0 {
+		query.WriteString(" ORDER BY " + strings.Join(s.orders, ", "))
+	}
+
+	if s.limit > 0 {
+		query.WriteString(fmt.Sprintf(" LIMIT %d", s.limit))
+	} else if s.offset > 0 {
+		// MySQL and SQLite do not support OFFSET without a LIMIT
+		switch databaseType {
+		case "mysql":
+			query.WriteString(" LIMIT 18446744073709551615")
+		case "sqlite3":
+			query.WriteString(" LIMIT -1")
+		}
+	}
+
+	if s.offset > 0 {
+		query.WriteString(fmt.Sprintf(" OFFSET %d", s.offset))
+	}
+
+	return query.String(), args
+}
+
+// Rebind converts the ? placeholders within the statement into the placeholders of the given database type.
+// PSQL placeholders are numbered from start, i.e. $1, $2. Placeholders within quoted strings are left untouched.
+func Rebind(stmt string, databaseType string, start int) string {
+	var parsedStmt strings.Builder
+	var quoted bool
+
+	if databaseType != "psql" {
+		return stmt
+	}
+
+	i := start
+	for _, v := range stmt {
+		if v == '\'' {
+			quoted = !quoted
+		}
+
+		if v == '?' && !quoted {
+			parsedStmt.WriteString("$" + strconv.Itoa(i))
+			i++
+			continue
+		}
+
+		parsedStmt.WriteRune(v)
+	}
+
+	return parsedStmt.String()
+}
+
+// Placeholders returns n comma separated ? placeholders, i.e. for use within an IN clause
+func Placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// ColumnPointers returns pointers to the fields of the model matching the given column names in the order of the columns.
+// Used when only a subset of columns is selected from the table.
+func ColumnPointers(model reflect.Value, columns []string) ([]any, error) {
+	var pointers []any
+
+	model = reflect.Indirect(model)
+	for _, c := range columns {
+		field, found := FieldByColumn(model.Type(), c)
+		if !found {
+			return nil, fmt.Errorf("no attribute was found on model %s for column %s", model.Type().Name(), c)
+		}
+
+		pointers = append(pointers, scanPointer(model.FieldByIndex(field.Index)))
+	}
+
+	return pointers, nil
+}
+
+// CoalesceColumnBuilder performs the same as CoalesceQueryBuilder but only for the given columns of the model
+func CoalesceColumnBuilder(model reflect.Type, columns []string) (string, error) {
+	var coalesceQuery []string
+
+	for _, c := range columns {
+		field, found := FieldByColumn(model, c)
+		if !found {
+			return "", fmt.Errorf("no attribute was found on model %s for column %s", model.Name(), c)
+		}
+
+		coalesceQuery = append(coalesceQuery, coalesceColumn(c, field.Type.Kind()))
+	}
+
+	return strings.Join(coalesceQuery, ", "), nil
+}
+
+// FieldByColumn returns the struct field of the model for the given column name
+func FieldByColumn(model reflect.Type, column string) (reflect.StructField, bool) {
+	f, found := ModelInfoOf(model).Field(column)
+
+	return f.StructField, found
+}
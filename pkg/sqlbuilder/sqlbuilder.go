@@ -6,8 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-
-	"github.com/google/uuid"
+	"time"
 )
 
 // Standard data manipulation operations
@@ -29,7 +28,6 @@ type Query struct {
 	model            any
 	Attributes       []string
 	mappedAttributes map[string]attribute
-	idPresent        bool
 }
 
 type attribute struct {
@@ -43,20 +41,12 @@ func serializeModelData(model any) *Query {
 		return &Query{Err: fmt.Errorf("pointer not passed. Please pass a pointer to the model")}
 	}
 
-	// Name of the struct itself, which is the DB table name
-	tableName := lowerSnakeCase(reflect.TypeOf(model).Elem().Name())
 	q := &Query{
 		model:            model,
-		TableName:        tableName,
+		TableName:        TableName(reflect.TypeOf(model).Elem()),
 		mappedAttributes: make(map[string]attribute),
 	}
 
-	// Check the pluralization of the tableName. If its not plural, pluralize it by adding s
-	// ToDO: Make this less pathetic
-	if !strings.HasSuffix(tableName, "s") {
-		q.TableName = tableName + "s"
-	}
-
 	nVal := reflect.Indirect(reflect.ValueOf(model))
 
 	// If slice, make higher level call deal with it.
@@ -65,24 +55,14 @@ func serializeModelData(model any) *Query {
 	}
 
 	// Parse attributes and values from passed in model
-	for i := 0; i < nVal.NumField(); i++ {
-		var name string
-		f := nVal.Type().Field(i)
+	info := ModelInfoOf(nVal.Type())
 
-		// If a DB tag is present, take this field instead. Else, parse field from struct attribute
+	for _, f := range info.Fields {
 		// If the models value is nil or empty, the attribute is removed
-		if t, ok := f.Tag.Lookup("db"); ok {
-			name = t
-		} else {
-			name = lowerSnakeCase(f.Name)
-		}
-
-		if name == "id" {
-			q.idPresent = true
-		}
+		name := f.Column
 
 		// Not sure if this is a good idea, if we exclued fields like this, could this lead to issues?
-		if value := nVal.Field(i); value.IsValid() && !value.IsZero() {
+		if value := nVal.Field(f.Index); value.IsValid() && !value.IsZero() {
 			v := value.Interface()
 			q.mappedAttributes[name] = attribute{value: v, t: value.Kind()}
 			q.Args = append(q.Args, v)
@@ -93,6 +73,39 @@ func serializeModelData(model any) *Query {
 	return q
 }
 
+// TableName derives the database table name from the model type.
+// The name of the struct itself is the DB table name, unnamed slices use the name of the slice element.
+func TableName(model reflect.Type) string {
+	for model.Name() == "" && (model.Kind() == reflect.Slice || model.Kind() == reflect.Ptr) {
+		model = model.Elem()
+	}
+
+	if model.Kind() == reflect.Struct {
+		return ModelInfoOf(model).TableName
+	}
+
+	return tableName(model)
+}
+
+// Derives the table name from the name of the type, unnamed slices and pointers use the name of the element
+func tableName(model reflect.Type) string {
+	name := model.Name()
+	for name == "" && (model.Kind() == reflect.Slice || model.Kind() == reflect.Ptr) {
+		model = model.Elem()
+		name = model.Name()
+	}
+
+	tableName := lowerSnakeCase(name)
+
+	// Check the pluralization of the tableName. If its not plural, pluralize it by adding s
+	// ToDO: Make this less pathetic
+	if !strings.HasSuffix(tableName, "s") {
+		return tableName + "s"
+	}
+
+	return tableName
+}
+
 func (q *Query) buildQueryFromModelData(queryType string, databaseType string) Query {
 	var queryString strings.Builder
 
@@ -124,27 +137,17 @@ func (q *Query) buildQueryFromModelData(queryType string, databaseType string) Q
 }
 
 func (q *Query) ModelAttributes() []any {
-	var pointers []any
-
 	vals := reflect.ValueOf(q.model).Elem()
-	for i := 0; i < vals.NumField(); i++ {
-		pointers = append(pointers, vals.Field(i).Addr().Interface())
-	}
 
-	return pointers
+	return ModelInfoOf(vals.Type()).Pointers(vals)
 }
 
 // Reflect the attributes from given reflect.Value and passed back slice of pointers to found attributes
 // Generally to be used for destructuring a reflect.Slice type
 func PointerAttributes(model reflect.Value) []any {
-	var pointers []any
-
 	model = reflect.Indirect(model)
-	for i := 0; i < model.NumField(); i++ {
-		pointers = append(pointers, model.Field(i).Addr().Interface())
-	}
 
-	return pointers
+	return ModelInfoOf(model.Type()).Pointers(model)
 }
 
 func IsPointer(model any) bool {
@@ -181,49 +184,63 @@ func QueryBuilder(queryType string, model any, databaseType string) Query {
 // This will avoid errors when null data is found when using find/where
 // Uses all types and names of attributes from passed in model
 func CoalesceQueryBuilder(model reflect.Type) string {
-	var coalesceQuery strings.Builder
-	coalesceString := " COALESCE"
+	return ModelInfoOf(model).SelectList
+}
 
-	for i := 0; i < model.NumField(); i++ {
-		var name string
-		val := model.Field(i)
-		// If the models value is nil or empty, the attribute is removed
-		if t, ok := val.Tag.Lookup("db"); ok {
-			name = t
-		} else {
-			name = lowerSnakeCase(val.Name)
-		}
-		switch val.Type.Kind() {
-		case reflect.String:
-			coalesceQuery.WriteString(fmt.Sprintf(" %s(%s, %s),", coalesceString, name, "''"))
-		case reflect.Array:
-			// This generally would mean a jsonb array or other
-			if name == "id" {
-				coalesceQuery.WriteString(fmt.Sprintf(" %s(%s, %v),", coalesceString, name, "'00000000-00000000-00000000-00000000'"))
+// QualifiedCoalesceQueryBuilder performs the same as CoalesceQueryBuilder, prefixing each column with the table name.
+// Used when joining tables that may share column names.
+func QualifiedCoalesceQueryBuilder(model reflect.Type, tableName string) string {
+	var coalesceQuery []string
 
-				continue
-			}
+	for _, f := range ModelInfoOf(model).Fields {
+		coalesceQuery = append(coalesceQuery, coalesceColumn(tableName+"."+f.Column, f.Type.Kind()))
+	}
+
+	return strings.Join(coalesceQuery, ", ")
+}
 
-			coalesceQuery.WriteString(fmt.Sprintf(" %s(%s, '%v'),", coalesceString, name, [0]any{}))
-		case reflect.Map:
-			// jsonb column
-			coalesceQuery.WriteString(fmt.Sprintf(" %s(%s, '{}'),", coalesceString, name))
-		case reflect.Int8, reflect.Uint16, reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Uint8:
-			coalesceQuery.WriteString(fmt.Sprintf(" %s(%s, %d),", coalesceString, name, 0))
-		case reflect.Bool:
-			coalesceQuery.WriteString(fmt.Sprintf(" %s(%s, %v),", coalesceString, name, false))
-		case reflect.Float64, reflect.Float32:
-			coalesceQuery.WriteString(fmt.Sprintf(" %s(%s, %f),", coalesceString, name, 0.0))
-		case reflect.Interface:
-			// Best guess, try string?
-			coalesceQuery.WriteString(fmt.Sprintf(" %s(%s, %v),", coalesceString, name, ""))
-		case reflect.Slice:
-			// Any slice
-			coalesceQuery.WriteString(fmt.Sprintf(" %s(%s, '%v'),", coalesceString, name, []any{}))
+// Wraps the column in COALESCE with the default value for the given kind.
+// Kinds without a default, i.e. time.Time and pointers, are selected as is so the selected columns always match the model attributes.
+func coalesceColumn(name string, kind reflect.Kind) string {
+	coalesceString := "COALESCE"
+
+	switch kind {
+	case reflect.String:
+		return fmt.Sprintf("%s(%s, %s)", coalesceString, name, "''")
+	case reflect.Array:
+		// This generally would mean a jsonb array or other
+		if name == "id" || strings.HasSuffix(name, ".id") {
+			return fmt.Sprintf("%s(%s, %v)", coalesceString, name, "'00000000-00000000-00000000-00000000'")
 		}
+
+		return fmt.Sprintf("%s(%s, '%v')", coalesceString, name, [0]any{})
+	case reflect.Map:
+		// jsonb column
+		return fmt.Sprintf("%s(%s, '{}')", coalesceString, name)
+	case reflect.Int8, reflect.Uint16, reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Uint8:
+		return fmt.Sprintf("%s(%s, %d)", coalesceString, name, 0)
+	case reflect.Bool:
+		return fmt.Sprintf("%s(%s, %v)", coalesceString, name, false)
+	case reflect.Float64, reflect.Float32:
+		return fmt.Sprintf("%s(%s, %f)", coalesceString, name, 0.0)
+	case reflect.Interface:
+		// Best guess, try string?
+		return fmt.Sprintf("%s(%s, %v)", coalesceString, name, "")
+	case reflect.Slice:
+		// Any slice
+		return fmt.Sprintf("%s(%s, '%v')", coalesceString, name, []any{})
+	}
+
+	return name
+}
+
+// Column name of the given struct field. If a DB tag is present, take this field instead. Else, parse field from struct attribute
+func columnName(field reflect.StructField) string {
+	if t, ok := field.Tag.Lookup("db"); ok {
+		return t
 	}
 
-	return strings.TrimSpace(strings.TrimSuffix(coalesceQuery.String(), ","))
+	return lowerSnakeCase(field.Name)
 }
 
 // Maps out values pulled from struct pointer and parses data into a string
@@ -235,15 +252,6 @@ func (q *Query) createTableString(databaseType string) string {
 	colString.WriteString("(")
 	valString.WriteString("(")
 
-	// If ID was not passed with model record being created, generate one.
-	// This will only execute if the id even exists on the model
-	if _, found := q.mappedAttributes["id"]; !found && q.idPresent {
-		id := uuid.New()
-		q.Attributes = append(q.Attributes, "id")
-		q.Args = append(q.Args, id)
-		q.mappedAttributes["id"] = attribute{value: id, t: reflect.TypeOf(id).Kind()}
-	}
-
 	for i, v := range q.Attributes {
 		// PSQL uses $ for values
 		if databaseType == "psql" {
@@ -305,19 +313,57 @@ func (q *Query) deleteString(databaseType string) string {
 
 	// No ID is present, do any fields have values?
 	for i, attr := range q.Attributes {
-		iter := strconv.Itoa(i + 1)
+		symbol := valSymbol
+		if databaseType == "psql" {
+			symbol += strconv.Itoa(i + 1)
+		}
+
 		if i == 0 {
-			s.WriteString(fmt.Sprintf("WHERE %s = %s", attr, (valSymbol + iter)))
+			s.WriteString(fmt.Sprintf("WHERE %s = %s", attr, symbol))
 
 			continue
 		}
 
-		s.WriteString(fmt.Sprintf(" AND %s = %s", attr, (valSymbol + iter)))
+		s.WriteString(fmt.Sprintf(" AND %s = %s", attr, symbol))
 	}
 
 	return s.String()
 }
 
+// SoftDeleteQuery builds the UPDATE query setting the soft delete column of the rows matching the model to deletedAt.
+// Rows are matched the same as Delete, rows which are already soft deleted are not updated.
+func SoftDeleteQuery(model any, column string, deletedAt time.Time, databaseType string) Query {
+	q := serializeModelData(model)
+	if q.Err != nil {
+		return *q
+	}
+
+	q.removeAttribute(column)
+
+	// Built using ? placeholders, as the deleted at argument precedes the arguments of the WHERE clause
+	where := q.deleteString("")
+	if where == "" {
+		return *q
+	}
+
+	q.Query = Rebind(fmt.Sprintf("%s %s SET %s = ? %s AND %s IS NULL", UPDATE, q.TableName, column, where, column), databaseType, 1)
+	q.Args = append([]any{deletedAt}, q.Args...)
+
+	return *q
+}
+
+func (q *Query) removeAttribute(name string) {
+	for i, a := range q.Attributes {
+		if a == name {
+			q.Attributes = append(q.Attributes[:i], q.Attributes[i+1:]...)
+			q.Args = append(q.Args[:i], q.Args[i+1:]...)
+			delete(q.mappedAttributes, name)
+
+			return
+		}
+	}
+}
+
 func (q *Query) updateString(databaseType string) (string, error) {
 	var s strings.Builder
 	var valSymbol string = "?"
@@ -358,6 +404,44 @@ func (q *Query) updateString(databaseType string) (string, error) {
 	return s.String(), nil
 }
 
+// UpdateColumnsQuery builds the UPDATE query setting the given columns of the row matching the id, i.e. UPDATE users SET name = $1, age = $2 WHERE id = $3
+// Unlike Update, the values of the columns are written even when empty.
+func UpdateColumnsQuery(tableName string, columns []string, databaseType string) string {
+	var sets []string
+
+	for _, c := range columns {
+		sets = append(sets, c+" = ?")
+	}
+
+	return Rebind(fmt.Sprintf("%s %s SET %s WHERE id = ?", UPDATE, tableName, strings.Join(sets, ", ")), databaseType, 1)
+}
+
+// ColumnNames returns all columns of the model in the order of the model attributes
+func ColumnNames(model reflect.Type) []string {
+	// Copied so the caller may modify the columns without changing the cached model metadata
+	return append([]string(nil), ModelInfoOf(model).Columns...)
+}
+
+// ColumnValues returns the values of the model for the given columns, including empty values
+func ColumnValues(model reflect.Value, columns []string) ([]any, error) {
+	var values []any
+
+	model = reflect.Indirect(model)
+	info := ModelInfoOf(model.Type())
+	modelValues := info.Values(model)
+
+	for _, c := range columns {
+		i, found := info.byColumn[c]
+		if !found {
+			return nil, fmt.Errorf("no attribute was found on model %s for column %s", model.Type().Name(), c)
+		}
+
+		values = append(values, modelValues[i])
+	}
+
+	return values, nil
+}
+
 func (q *Query) GetModelID() any {
 	if v, found := q.mappedAttributes["id"]; found {
 		return v.value
@@ -1,6 +1,14 @@
 package sqlbuilder
 
-import "testing"
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/BitlyTwiser/tinyORM/pkg/custom"
+	"github.com/google/uuid"
+)
 
 func TestSnakeCase(t *testing.T) {
 	tests := map[string]struct {
@@ -24,3 +32,324 @@ func TestSnakeCase(t *testing.T) {
 		})
 	}
 }
+
+func TestRebind(t *testing.T) {
+	tests := map[string]struct {
+		have         string
+		databaseType string
+		start        int
+		want         string
+	}{
+		"Test psql placeholders":            {have: "name = ? AND age > ?", databaseType: "psql", start: 1, want: "name = $1 AND age > $2"},
+		"Test psql placeholders offset":     {have: "name = ?", databaseType: "psql", start: 3, want: "name = $3"},
+		"Test psql quoted question mark":    {have: "name = '?' AND age > ?", databaseType: "psql", start: 1, want: "name = '?' AND age > $1"},
+		"Test mysql placeholders unchanged": {have: "name = ? AND age > ?", databaseType: "mysql", start: 1, want: "name = ? AND age > ?"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if have := Rebind(test.have, test.databaseType, test.start); have != test.want {
+				t.Fatalf("Wanted: %s - Have: %s", test.want, have)
+			}
+		})
+	}
+}
+
+func TestSelectQueryBuild(t *testing.T) {
+	newQuery := func() *SelectQuery {
+		q := &SelectQuery{TableName: "users", Columns: "name"}
+		q.Where("age > ?", 18)
+		q.Where("name = ? OR name = ?", "Carl", "Bob")
+		q.Order("created_at desc")
+
+		return q
+	}
+
+	tests := map[string]struct {
+		databaseType string
+		limit        int
+		offset       int
+		want         string
+	}{
+		"Test psql":               {databaseType: "psql", limit: 10, offset: 20, want: "SELECT name FROM users WHERE (age > $1) AND (name = $2 OR name = $3) ORDER BY created_at desc LIMIT 10 OFFSET 20"},
+		"Test mysql":              {databaseType: "mysql", limit: 10, want: "SELECT name FROM users WHERE (age > ?) AND (name = ? OR name = ?) ORDER BY created_at desc LIMIT 10"},
+		"Test sqlite offset only": {databaseType: "sqlite3", offset: 5, want: "SELECT name FROM users WHERE (age > ?) AND (name = ? OR name = ?) ORDER BY created_at desc LIMIT -1 OFFSET 5"},
+		"Test mysql offset only":  {databaseType: "mysql", offset: 5, want: "SELECT name FROM users WHERE (age > ?) AND (name = ? OR name = ?) ORDER BY created_at desc LIMIT 18446744073709551615 OFFSET 5"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q := newQuery()
+			q.Limit(test.limit)
+			q.Offset(test.offset)
+
+			query, args := q.Build(test.databaseType)
+			if query != test.want {
+				t.Fatalf("Wanted: %s - Have: %s", test.want, query)
+			}
+
+			if len(args) != 3 {
+				t.Fatalf("Wanted: 3 args - Have: %d", len(args))
+			}
+		})
+	}
+}
+
+func TestCreateTableQuery(t *testing.T) {
+	type Vehicle struct {
+		ID            uuid.UUID
+		Manufacturers custom.Slice
+		Data          custom.Map
+		Color         string
+		Recall        bool
+		Miles         int
+		Price         float64 `db:"cost"`
+	}
+
+	tests := map[string]struct {
+		databaseType string
+		want         string
+	}{
+		"Test psql":   {databaseType: "psql", want: "CREATE TABLE IF NOT EXISTS vehicles (id UUID PRIMARY KEY, manufacturers JSONB, data JSONB, color TEXT, recall BOOLEAN, miles BIGINT, cost DOUBLE PRECISION)"},
+		"Test mysql":  {databaseType: "mysql", want: "CREATE TABLE IF NOT EXISTS vehicles (id BINARY(36) PRIMARY KEY, manufacturers JSON, data JSON, color TEXT, recall BOOLEAN, miles BIGINT, cost DOUBLE)"},
+		"Test sqlite": {databaseType: "sqlite3", want: "CREATE TABLE IF NOT EXISTS vehicles (id TEXT PRIMARY KEY, manufacturers TEXT, data TEXT, color TEXT, recall BOOLEAN, miles INTEGER, cost REAL)"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if have := CreateTableQuery(reflect.TypeOf(Vehicle{}), test.databaseType); have != test.want {
+				t.Fatalf("Wanted: %s - Have: %s", test.want, have)
+			}
+		})
+	}
+}
+
+func TestSoftDeleteQuery(t *testing.T) {
+	type Account struct {
+		ID        int
+		Name      string
+		Age       int
+		DeletedAt *time.Time
+	}
+
+	now := time.Now()
+	tests := map[string]struct {
+		model        *Account
+		databaseType string
+		want         string
+		args         int
+	}{
+		"Test psql id":        {model: &Account{ID: 1, Name: "carl"}, databaseType: "psql", want: "update accounts SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL", args: 2},
+		"Test mysql id":       {model: &Account{ID: 1, DeletedAt: &now}, databaseType: "mysql", want: "update accounts SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL", args: 2},
+		"Test psql attribute": {model: &Account{Name: "carl", Age: 30}, databaseType: "psql", want: "update accounts SET deleted_at = $1 WHERE name = $2 AND age = $3 AND deleted_at IS NULL", args: 3},
+		"Test sqlite empty":   {model: &Account{}, databaseType: "sqlite3", want: "", args: 0},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q := SoftDeleteQuery(test.model, "deleted_at", now, test.databaseType)
+			if q.Query != test.want {
+				t.Fatalf("Wanted: %s - Have: %s", test.want, q.Query)
+			}
+
+			if test.want != "" && (len(q.Args) != test.args || q.Args[0] != now) {
+				t.Fatalf("Wanted: %d args starting with the deleted at time - Have: %v", test.args, q.Args)
+			}
+		})
+	}
+}
+
+func TestTimeScanner(t *testing.T) {
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := map[string]struct {
+		src  any
+		want time.Time
+	}{
+		"Test time":          {src: want, want: want},
+		"Test mysql bytes":   {src: []byte("2024-01-02 03:04:05"), want: want},
+		"Test sqlite string": {src: "2024-01-02 03:04:05+00:00", want: want},
+		"Test rfc3339":       {src: "2024-01-02T03:04:05Z", want: want},
+		"Test null":          {src: nil, want: time.Time{}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var value time.Time
+			var pointer *time.Time
+			var null sql.NullTime
+
+			for _, dest := range []any{&value, &pointer, &null} {
+				if err := scanPointer(reflect.ValueOf(dest).Elem()).(sql.Scanner).Scan(test.src); err != nil {
+					t.Fatalf("error scanning %v. error: %v", test.src, err.Error())
+				}
+			}
+
+			if !value.Equal(test.want) || null.Time != value || null.Valid == test.want.IsZero() {
+				t.Fatalf("Wanted: %v - Have: %v and %v", test.want, value, null)
+			}
+
+			if (pointer == nil) != test.want.IsZero() || (pointer != nil && !pointer.Equal(test.want)) {
+				t.Fatalf("Wanted: %v - Have: %v", test.want, pointer)
+			}
+		})
+	}
+}
+
+func TestInsertQuery(t *testing.T) {
+	tests := map[string]struct {
+		databaseType string
+		want         string
+	}{
+		"Test psql":   {databaseType: "psql", want: "INSERT INTO users (id, name) VALUES ($1, $2), ($3, $4), ($5, $6)"},
+		"Test mysql":  {databaseType: "mysql", want: "INSERT INTO users (id, name) VALUES (?, ?), (?, ?), (?, ?)"},
+		"Test sqlite": {databaseType: "sqlite3", want: "INSERT INTO users (id, name) VALUES (?, ?), (?, ?), (?, ?)"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if have := InsertQuery("users", []string{"id", "name"}, 3, test.databaseType); have != test.want {
+				t.Fatalf("Wanted: %s - Have: %s", test.want, have)
+			}
+		})
+	}
+}
+
+func TestUpsertQuery(t *testing.T) {
+	type User struct {
+		ID   int
+		Name string
+		Age  int
+	}
+
+	tests := map[string]struct {
+		conflict     []string
+		update       []string
+		databaseType string
+		want         string
+		err          bool
+	}{
+		"Test psql update":    {conflict: []string{"id"}, update: []string{"name", "age"}, databaseType: "psql", want: "insert INTO users (id, name, age) VALUES ($1, $2, $3) ON CONFLICT (id) DO UPDATE SET name = excluded.name, age = excluded.age"},
+		"Test sqlite nothing": {conflict: []string{"id"}, databaseType: "sqlite3", want: "insert INTO users (id, name, age) VALUES (?, ?, ?) ON CONFLICT (id) DO NOTHING"},
+		"Test mysql update":   {conflict: []string{"id"}, update: []string{"name"}, databaseType: "mysql", want: "insert INTO users (id, name, age) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name)"},
+		"Test mysql nothing":  {databaseType: "mysql", want: "insert INTO users (id, name, age) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE id = id"},
+		"Test missing target": {update: []string{"name"}, databaseType: "psql", err: true},
+		"Test unknown column": {conflict: []string{"email"}, databaseType: "psql", err: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q := UpsertQuery(&User{ID: 1, Name: "carl", Age: 30}, test.conflict, test.update, test.databaseType)
+			if (q.Err != nil) != test.err {
+				t.Fatalf("Wanted error: %v - Have: %v", test.err, q.Err)
+			}
+
+			if !test.err && q.Query != test.want {
+				t.Fatalf("Wanted: %s - Have: %s", test.want, q.Query)
+			}
+		})
+	}
+}
+
+func TestUpdateColumnsQuery(t *testing.T) {
+	tests := map[string]struct {
+		columns      []string
+		databaseType string
+		want         string
+	}{
+		"Test psql":   {columns: []string{"name", "age"}, databaseType: "psql", want: "update users SET name = $1, age = $2 WHERE id = $3"},
+		"Test mysql":  {columns: []string{"age"}, databaseType: "mysql", want: "update users SET age = ? WHERE id = ?"},
+		"Test sqlite": {columns: []string{"name", "age"}, databaseType: "sqlite3", want: "update users SET name = ?, age = ? WHERE id = ?"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if q := UpdateColumnsQuery("users", test.columns, test.databaseType); q != test.want {
+				t.Fatalf("Wanted: %s - Have: %s", test.want, q)
+			}
+		})
+	}
+}
+
+func TestModelInfo(t *testing.T) {
+	type Member struct {
+		ID        int
+		FullName  string             `db:"name"`
+		Ignored   string             `db:"-"`
+		Posts     []struct{ ID int } `tinyorm:"has_many"`
+		DeletedAt *time.Time
+	}
+
+	info := ModelInfoOf(reflect.TypeOf(Member{}))
+
+	if info != ModelInfoOf(reflect.TypeOf(Member{})) {
+		t.Fatal("Wanted: the cached model info - Have: a new model info")
+	}
+
+	tests := map[string]struct {
+		have any
+		want any
+	}{
+		"Test table name":  {have: info.TableName, want: "members"},
+		"Test columns":     {have: info.Columns, want: []string{"id", "name", "deleted_at"}},
+		"Test primary key": {have: info.PrimaryKey, want: 0},
+		"Test select list": {have: info.SelectList, want: "COALESCE(id, 0), COALESCE(name, ''), deleted_at"},
+		"Test field index": {have: info.Fields[1].Index, want: 1},
+		"Test time field":  {have: info.Fields[2].Time, want: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if !reflect.DeepEqual(test.have, test.want) {
+				t.Fatalf("Wanted: %v - Have: %v", test.want, test.have)
+			}
+		})
+	}
+
+	t.Run("Test field by column", func(t *testing.T) {
+		f, found := FieldByColumn(reflect.TypeOf(Member{}), "name")
+		if !found || f.Name != "FullName" {
+			t.Fatalf("Wanted: FullName - Have: %v", f.Name)
+		}
+
+		if _, found := FieldByColumn(reflect.TypeOf(Member{}), "posts"); found {
+			t.Fatal("Wanted: relations are not columns - Have: posts found")
+		}
+	})
+}
+
+func BenchmarkPointerAttributes(b *testing.B) {
+	type Member struct {
+		ID        uuid.UUID
+		Name      string
+		Email     string
+		Age       int
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+
+	model := reflect.ValueOf(&Member{})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PointerAttributes(model)
+	}
+}
+
+func BenchmarkCoalesceQueryBuilder(b *testing.B) {
+	type Member struct {
+		ID        uuid.UUID
+		Name      string
+		Email     string
+		Age       int
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+
+	model := reflect.TypeOf(Member{})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		CoalesceQueryBuilder(model)
+	}
+}
@@ -0,0 +1,219 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Relation kinds declared within the tinyorm struct tag, i.e. `tinyorm:"has_many,foreign_key=user_id"`
+const (
+	HAS_ONE      = "has_one"
+	HAS_MANY     = "has_many"
+	BELONGS_TO   = "belongs_to"
+	MANY_TO_MANY = "many2many"
+)
+
+// Attribute flags declared within the tinyorm struct tag
+const (
+	SOFT_DELETE = "soft_delete" // Attribute holding the soft delete timestamp, i.e. `tinyorm:"soft_delete"`
+	VERSION     = "version"     // Integer attribute used for optimistic locking, i.e. `tinyorm:"version"`
+)
+
+// Relation describes an association between a model and another model declared by the tinyorm struct tag
+type Relation struct {
+	Kind       string
+	Field      reflect.StructField
+	Model      reflect.Type // Struct type of the associated model
+	ForeignKey string
+	// Many to many relations are stored within a join table.
+	// JoinForeignKey references the model, JoinReferences references the associated model.
+	JoinTable      string
+	JoinForeignKey string
+	JoinReferences string
+}
+
+// Parses the comma separated tinyorm struct tag. Entries are either flags (has_many) or key value pairs (foreign_key=user_id)
+func parseTag(field reflect.StructField) map[string]string {
+	options := make(map[string]string)
+
+	tag, ok := field.Tag.Lookup("tinyorm")
+	if !ok {
+		return options
+	}
+
+	for _, entry := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		if key != "" {
+			options[key] = value
+		}
+	}
+
+	return options
+}
+
+// Determines if the struct field is a table column. Relations and fields tagged with db:"-" are not columns.
+func isColumn(field reflect.StructField) bool {
+	if field.Tag.Get("db") == "-" {
+		return false
+	}
+
+	return relationKind(parseTag(field)) == ""
+}
+
+func relationKind(options map[string]string) string {
+	for _, kind := range []string{HAS_ONE, HAS_MANY, BELONGS_TO, MANY_TO_MANY} {
+		if _, found := options[kind]; found {
+			return kind
+		}
+	}
+
+	return ""
+}
+
+// Relations returns all relations declared on the model
+func Relations(model reflect.Type) ([]Relation, error) {
+	var relations []Relation
+
+	for i := 0; i < model.NumField(); i++ {
+		if relationKind(parseTag(model.Field(i))) == "" {
+			continue
+		}
+
+		r, err := RelationOf(model, model.Field(i).Name)
+		if err != nil {
+			return nil, err
+		}
+
+		relations = append(relations, r)
+	}
+
+	return relations, nil
+}
+
+// JoinTableQuery builds the CREATE TABLE IF NOT EXISTS query for the join table of a many to many relation.
+// The key column types match the id types of both models.
+func JoinTableQuery(model reflect.Type, r Relation, databaseType string) string {
+	modelKey, referenceKey := joinKeyType(model, databaseType), joinKeyType(r.Model, databaseType)
+
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s %s NOT NULL, %s %s NOT NULL, PRIMARY KEY (%s, %s))", r.JoinTable, r.JoinForeignKey, modelKey, r.JoinReferences, referenceKey, r.JoinForeignKey, r.JoinReferences)
+}
+
+// Column type of the join table key referencing the id of the model
+func joinKeyType(model reflect.Type, databaseType string) string {
+	f, found := FieldByColumn(model, "id")
+	if !found || f.Type.Kind() == reflect.String {
+		// MySQL cannot index TEXT columns without a length
+		return dialectType(databaseType, "TEXT", "VARCHAR(255)", "TEXT")
+	}
+
+	return ColumnType(f.Type, databaseType)
+}
+
+// RelationOf returns the relation declared on the named field of the model.
+// The foreign key defaults to the snake cased model name suffixed with _id for has_one and has_many, i.e. user_id.
+// For belongs_to the foreign key defaults to the snake cased field name suffixed with _id.
+func RelationOf(model reflect.Type, fieldName string) (Relation, error) {
+	field, found := model.FieldByName(fieldName)
+	if !found {
+		return Relation{}, fmt.Errorf("no attribute %s was found on model %s", fieldName, model.Name())
+	}
+
+	options := parseTag(field)
+	r := Relation{Kind: relationKind(options), Field: field, Model: field.Type, ForeignKey: options["foreign_key"]}
+
+	if r.Kind == "" {
+		return r, fmt.Errorf("attribute %s on model %s does not declare a relation", fieldName, model.Name())
+	}
+
+	for r.Model.Kind() == reflect.Ptr || r.Model.Kind() == reflect.Slice {
+		r.Model = r.Model.Elem()
+	}
+
+	if r.Model.Kind() != reflect.Struct {
+		return r, fmt.Errorf("attribute %s on model %s must be a struct, pointer to a struct or a slice of structs", fieldName, model.Name())
+	}
+
+	if (r.Kind == HAS_MANY || r.Kind == MANY_TO_MANY) && field.Type.Kind() != reflect.Slice {
+		return r, fmt.Errorf("%s attribute %s on model %s must be a slice", r.Kind, fieldName, model.Name())
+	}
+
+	// The join table defaults to the snake cased model name and the associated table, i.e. user_tags
+	if r.Kind == MANY_TO_MANY {
+		r.JoinTable = options[MANY_TO_MANY]
+		if r.JoinTable == "" {
+			r.JoinTable = lowerSnakeCase(model.Name()) + "_" + TableName(r.Model)
+		}
+
+		r.JoinForeignKey = options["join_foreign_key"]
+		if r.JoinForeignKey == "" {
+			r.JoinForeignKey = lowerSnakeCase(model.Name()) + "_id"
+		}
+
+		r.JoinReferences = options["join_references"]
+		if r.JoinReferences == "" {
+			r.JoinReferences = lowerSnakeCase(r.Model.Name()) + "_id"
+		}
+
+		return r, nil
+	}
+
+	if r.ForeignKey == "" {
+		if r.Kind == BELONGS_TO {
+			r.ForeignKey = lowerSnakeCase(field.Name) + "_id"
+		} else {
+			r.ForeignKey = lowerSnakeCase(model.Name()) + "_id"
+		}
+	}
+
+	return r, nil
+}
+
+// SoftDeleteField returns the attribute and column holding the soft delete timestamp of the model.
+// The attribute tagged with soft_delete is used, otherwise the attribute mapped to the deleted_at column.
+// The attribute must be a *time.Time or sql.NullTime so rows which are not deleted can hold NULL.
+func SoftDeleteField(model reflect.Type) (reflect.StructField, string, bool) {
+	var deletedAt reflect.StructField
+	var found bool
+
+	var column string
+
+	for _, f := range ModelInfoOf(model).Fields {
+		if f.Type != timePointerType && f.Type != nullTimeType {
+			continue
+		}
+
+		if _, tagged := f.Options[SOFT_DELETE]; tagged {
+			return f.StructField, f.Column, true
+		}
+
+		if !found && f.Column == "deleted_at" {
+			deletedAt, column, found = f.StructField, f.Column, true
+		}
+	}
+
+	return deletedAt, column, found
+}
+
+// SoftDeleteColumn returns the column holding the soft delete timestamp of the model, if any
+func SoftDeleteColumn(model reflect.Type) (string, bool) {
+	_, column, found := SoftDeleteField(model)
+
+	return column, found
+}
+
+// VersionField returns the integer attribute and column tagged with version, used for optimistic locking
+func VersionField(model reflect.Type) (reflect.StructField, string, bool) {
+	for _, f := range ModelInfoOf(model).Fields {
+		if _, tagged := f.Options[VERSION]; !tagged {
+			continue
+		}
+
+		switch f.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return f.StructField, f.Column, true
+		}
+	}
+
+	return reflect.StructField{}, "", false
+}
@@ -0,0 +1,101 @@
+package sqlbuilder
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var timePointerType = reflect.PointerTo(timeType)
+
+// Layouts of the timestamps returned as text, i.e. MySQL without parseTime and SQLite columns not declared as a time type
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// IsTime determines if the type holds a timestamp: time.Time, *time.Time or sql.NullTime
+func IsTime(t reflect.Type) bool {
+	return t == timeType || t == timePointerType || t == nullTimeType
+}
+
+// TimeField returns the attribute of the model mapped to the column if the attribute holds a timestamp
+func TimeField(model reflect.Type, column string) (reflect.StructField, bool) {
+	f, found := FieldByColumn(model, column)
+	if !found || !IsTime(f.Type) {
+		return reflect.StructField{}, false
+	}
+
+	return f, true
+}
+
+// timeScanner scans NULL and textual timestamps into time attributes.
+// NULL is scanned as the zero time for time.Time attributes, as a nil pointer for *time.Time and an invalid sql.NullTime.
+type timeScanner struct {
+	dest reflect.Value
+}
+
+func (ts *timeScanner) Scan(src any) error {
+	var t time.Time
+
+	switch v := src.(type) {
+	case nil:
+		ts.dest.Set(reflect.Zero(ts.dest.Type()))
+
+		return nil
+	case time.Time:
+		t = v
+	case []byte:
+		parsed, err := parseTime(string(v))
+		if err != nil {
+			return err
+		}
+		t = parsed
+	case string:
+		parsed, err := parseTime(v)
+		if err != nil {
+			return err
+		}
+		t = parsed
+	default:
+		return fmt.Errorf("cannot scan %T into %s", src, ts.dest.Type())
+	}
+
+	switch ts.dest.Type() {
+	case timePointerType:
+		ts.dest.Set(reflect.ValueOf(&t))
+	case nullTimeType:
+		ts.dest.Set(reflect.ValueOf(sql.NullTime{Time: t, Valid: true}))
+	default:
+		ts.dest.Set(reflect.ValueOf(t))
+	}
+
+	return nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("cannot parse %s as a timestamp", s)
+}
+
+// Returns the pointer scanned into for the attribute. Time attributes are wrapped to support NULL and textual timestamps.
+func scanPointer(field reflect.Value) any {
+	if IsTime(field.Type()) {
+		return &timeScanner{dest: field}
+	}
+
+	return field.Addr().Interface()
+}
@@ -0,0 +1,83 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UpsertQuery builds the INSERT query for the model, updating the given columns of the existing row on conflict.
+// Postgres and SQLite use ON CONFLICT (conflictColumns) DO UPDATE. MySQL uses ON DUPLICATE KEY UPDATE, which conflicts on any unique key.
+// When no update columns are given the existing row is left untouched.
+func UpsertQuery(model any, conflictColumns []string, updateColumns []string, databaseType string) Query {
+	q := serializeModelData(model)
+	if q.Err != nil {
+		return *q
+	}
+
+	modelType := reflect.TypeOf(model).Elem()
+	if modelType.Kind() != reflect.Struct {
+		q.Err = fmt.Errorf("upsert expects a pointer to a struct")
+
+		return *q
+	}
+
+	for _, columns := range [][]string{conflictColumns, updateColumns} {
+		for _, c := range columns {
+			if _, found := FieldByColumn(modelType, c); !found {
+				q.Err = fmt.Errorf("no attribute was found on model %s for column %s", modelType.Name(), c)
+
+				return *q
+			}
+		}
+	}
+
+	if databaseType != "mysql" && len(conflictColumns) == 0 && len(updateColumns) > 0 {
+		q.Err = fmt.Errorf("conflict columns must be given to update the existing row")
+
+		return *q
+	}
+
+	insert := q.createTableString(databaseType)
+	if len(q.Attributes) == 0 {
+		q.Err = fmt.Errorf("no attributes were found on model %s to insert", modelType.Name())
+
+		return *q
+	}
+
+	q.Query = fmt.Sprintf("%s INTO %s %s%s", INSERT, q.TableName, insert, q.conflictString(conflictColumns, updateColumns, databaseType))
+
+	return *q
+}
+
+func (q *Query) conflictString(conflictColumns []string, updateColumns []string, databaseType string) string {
+	var sets []string
+
+	if databaseType == "mysql" {
+		// Assigning a column to itself leaves the existing row untouched
+		if len(updateColumns) == 0 {
+			return fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s = %s", q.Attributes[0], q.Attributes[0])
+		}
+
+		for _, c := range updateColumns {
+			sets = append(sets, fmt.Sprintf("%s = VALUES(%s)", c, c))
+		}
+
+		return " ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+	}
+
+	var target string
+	if len(conflictColumns) > 0 {
+		target = " (" + strings.Join(conflictColumns, ", ") + ")"
+	}
+
+	if len(updateColumns) == 0 {
+		return " ON CONFLICT" + target + " DO NOTHING"
+	}
+
+	for _, c := range updateColumns {
+		sets = append(sets, fmt.Sprintf("%s = excluded.%s", c, c))
+	}
+
+	return " ON CONFLICT" + target + " DO UPDATE SET " + strings.Join(sets, ", ")
+}
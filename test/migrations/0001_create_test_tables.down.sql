@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS test_no_ids;
+DROP TABLE IF EXISTS vehicles;
+DROP TABLE IF EXISTS users;
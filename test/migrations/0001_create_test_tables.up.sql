@@ -0,0 +1,3 @@
+CREATE TABLE IF NOT EXISTS users (id UUID PRIMARY KEY, name TEXT, email TEXT, username TEXT, password TEXT, age INT);
+CREATE TABLE IF NOT EXISTS vehicles (id UUID PRIMARY KEY, manufacturers JSONB, data JSONB, color TEXT, recall BOOLEAN);
+CREATE TABLE IF NOT EXISTS test_no_ids (stuff TEXT, data TEXT);
@@ -1,6 +1,7 @@
 package tinyorm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -9,6 +10,15 @@ import (
 	"github.com/BitlyTwiser/tinyORM/pkg/logger"
 )
 
+// ErrStaleObject is returned by Update when the version of the model no longer matches the version of the row
+var ErrStaleObject = dialects.ErrStaleObject
+
+// Result is returned from the write operations holding the rows affected and the id generated by the database
+type Result = dialects.Result
+
+// ConfirmAll confirms DeleteAll deletes every row of the table, i.e. db.DeleteAll(new(Users), tinyorm.ConfirmAll)
+const ConfirmAll = dialects.ConfirmAll
+
 // Connect is the primary entrypoint to tinyorm
 // Connect will accept variadic values of connection strings i.e. development, prod etc..
 // Each connection string must match a string present within the database.yml.
@@ -26,7 +36,7 @@ func Connect(connection string) (dialects.DialectHandler, error) {
 		return nil, logger.Log.LogError("error initializing database connection", err)
 	}
 
-	if handle, found := connections.Connections[connection]; found {
+	if handle, found := connections.Connection(connection); found {
 		return handle, nil
 	}
 
@@ -47,7 +57,7 @@ func MultiConnect(databaseConnections ...string) (dialects.MultiTenantDialectHan
 			continue
 		}
 
-		if handle, found := connections.Connections[c]; found {
+		if handle, found := connections.Connection(c); found {
 			handlers.Set(c, handle)
 		}
 	}
@@ -58,3 +68,47 @@ func MultiConnect(databaseConnections ...string) (dialects.MultiTenantDialectHan
 
 	return handlers, nil
 }
+
+// Disconnect closes the handler of the connection and removes it, the connection may be re-opened using Connect.
+// Waits for in-flight queries to finish up to dialects.DEFAULT_CLOSE_TIMEOUT.
+func Disconnect(connection string) error {
+	return connections.Close(connection)
+}
+
+// CloseAll closes every open connection, used for graceful shutdown.
+// Waits for the in-flight queries of all connections to finish up to dialects.DEFAULT_CLOSE_TIMEOUT.
+func CloseAll() error {
+	ctx, cancel := context.WithTimeout(context.Background(), dialects.DEFAULT_CLOSE_TIMEOUT)
+	defer cancel()
+
+	return CloseAllContext(ctx)
+}
+
+// CloseAllContext performs the same as CloseAll, waiting for in-flight queries until the context is done
+func CloseAllContext(ctx context.Context) error {
+	return connections.CloseAll(ctx)
+}
+
+// Preload loads the given associations of the model, pass alongside the arguments of Find or Where.
+// i.e. db.Find(users, tinyorm.Preload("Vehicles"))
+func Preload(associations ...string) dialects.Option {
+	return dialects.Preload(associations...)
+}
+
+// Unscoped includes soft deleted rows within the results, pass alongside the arguments of Find or Where.
+// i.e. db.Find(users, tinyorm.Unscoped())
+func Unscoped() dialects.Option {
+	return dialects.Unscoped()
+}
+
+// Columns limits Update to the given columns, the columns are written even when the attributes are empty.
+// i.e. db.Update(user, tinyorm.Columns("age", "active"))
+func Columns(columns ...string) dialects.Option {
+	return dialects.Columns(columns...)
+}
+
+// Unprepared returns a context whose queries skip the prepared statement cache, for one-off queries.
+// i.e. db.RawContext(tinyorm.Unprepared(ctx), query)
+func Unprepared(ctx context.Context) context.Context {
+	return dialects.Unprepared(ctx)
+}
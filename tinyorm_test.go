@@ -70,7 +70,7 @@ func TestCreate(t *testing.T) {
 
 	for name, test := range createTests {
 		t.Run(name, func(t *testing.T) {
-			if err := db.Create(test.model); err != nil {
+			if _, err := db.Create(test.model); err != nil {
 				t.Fatalf("error updating model. error: %v", err.Error())
 			}
 		})
@@ -126,14 +126,14 @@ func TestORMFunctionality(t *testing.T) {
 				if test.adjustModel {
 					test.adjustModelFunc(test.model)
 				}
-				if err := db.Create(test.model); err != nil {
+				if _, err := db.Create(test.model); err != nil {
 					t.Fatalf("error updating model. error: %v", err.Error())
 				}
 			case "update":
 				if test.adjustModel {
 					test.adjustModelFunc(test.model)
 				}
-				if err := db.Update(test.model); err != nil {
+				if _, err := db.Update(test.model); err != nil {
 					t.Fatalf("error updating model. error: %v", err.Error())
 				}
 			case "find":
@@ -172,7 +172,7 @@ func TestORMFunctionality(t *testing.T) {
 					test.adjustModelFunc(test.model)
 				}
 				if q, err := db.Raw(test.stmt, test.sliceArgs...); err == nil {
-					if err := q.Exec(); err != nil {
+					if _, err := q.Exec(); err != nil {
 						t.Fatalf("error executing raw query. %s", err.Error())
 					}
 				} else {
@@ -208,8 +208,8 @@ func TestDeleteData(t *testing.T) {
 		"Test Delete Vehicle using attributes":      {action: "delete", adjustModel: false, model: &Vehicle{Color: "Blue"}},
 		"Test Should not Delete User":               {action: "delete", adjustModel: false, model: new(User)},
 		"Test Should not Delete Vehicle":            {action: "delete", adjustModel: false, model: new(Vehicle)},
-		"Test Delete Users":                         {action: "delete-bulk", adjustModel: false, model: new(Users)},    // Will Delete ALL Users
-		"Test Delete Vehicles":                      {action: "delete-bulk", adjustModel: false, model: new(Vehicles)}, // Will Delete ALL Vehicles
+		"Test Delete Users":                         {action: "delete-all", adjustModel: false, model: new(Users)},    // Will Delete ALL Users
+		"Test Delete Vehicles":                      {action: "delete-all", adjustModel: false, model: new(Vehicles)}, // Will Delete ALL Vehicles
 		"Test Delete no ID model":                   {action: "delete", adjustModel: false, model: new(TestNoIDs)},
 	}
 
@@ -217,13 +217,13 @@ func TestDeleteData(t *testing.T) {
 		switch test.action {
 		case "delete":
 			t.Run(name, func(t *testing.T) {
-				if err := db.Delete(test.model); err != nil {
+				if _, err := db.Delete(test.model); err != nil {
 					t.Fatalf("error updating model. error: %v", err.Error())
 				}
 			})
-		case "delete-bulk":
+		case "delete-all":
 			t.Run(name, func(t *testing.T) {
-				if err := db.BulkDelete(test.model); err != nil {
+				if _, err := db.DeleteAll(test.model, tinyorm.ConfirmAll); err != nil {
 					t.Fatalf("error updating model. error: %v", err.Error())
 				}
 			})
@@ -341,11 +341,11 @@ func TestMultiTenant(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := mtc.SwitchDB("development").Create(&TestNoID{Stuff: "More Test PSQL"}); err != nil {
+	if _, err := mtc.SwitchDB("development").Create(&TestNoID{Stuff: "More Test PSQL"}); err != nil {
 		t.Fatalf("error creating test on psqlDB. error: %v", err.Error())
 	}
 
-	if err := mtc.SwitchDB("development-mysql").Create(&TestNoID{Stuff: "More Test MySql"}); err != nil {
+	if _, err := mtc.SwitchDB("development-mysql").Create(&TestNoID{Stuff: "More Test MySql"}); err != nil {
 		t.Fatalf("error creating test on mysql. error: %v", err.Error())
 	}
 }